@@ -2,7 +2,7 @@
 package allowlist
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -11,57 +11,32 @@ import (
 
 // List IP's allowlisted for an app.
 func List(c *drycc.Client, appID string) (api.Allowlist, error) {
-	u := fmt.Sprintf("/v2/apps/%s/allowlist/", appID)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Allowlist{}, reqErr
-	}
-	defer res.Body.Close()
-
-	allowlist := api.Allowlist{}
-	if err := json.NewDecoder(res.Body).Decode(&allowlist); err != nil {
-		return api.Allowlist{}, err
-	}
+	return ListWithContext(context.Background(), c, appID)
+}
 
-	return allowlist, reqErr
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string) (api.Allowlist, error) {
+	return drycc.Get[api.Allowlist](ctx, c, fmt.Sprintf("/v2/apps/%s/allowlist/", appID))
 }
 
 // Add adds addresses to an app's allowlist.
 func Add(c *drycc.Client, appID string, addresses []string) (api.Allowlist, error) {
-	u := fmt.Sprintf("/v2/apps/%s/allowlist/", appID)
+	return AddWithContext(context.Background(), c, appID, addresses)
+}
 
+// AddWithContext is like [Add] but carries ctx.
+func AddWithContext(ctx context.Context, c *drycc.Client, appID string, addresses []string) (api.Allowlist, error) {
 	req := api.Allowlist{Addresses: addresses}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Allowlist{}, err
-	}
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Allowlist{}, reqErr
-	}
-	defer res.Body.Close()
-
-	d := api.Allowlist{}
-	if err = json.NewDecoder(res.Body).Decode(&d); err != nil {
-		return api.Allowlist{}, err
-	}
-
-	return d, reqErr
+	return drycc.Post[api.Allowlist](ctx, c, fmt.Sprintf("/v2/apps/%s/allowlist/", appID), req)
 }
 
 // Delete removes addresses from an app's allowlist.
 func Delete(c *drycc.Client, appID string, addresses []string) error {
-	u := fmt.Sprintf("/v2/apps/%s/allowlist/", appID)
+	return DeleteWithContext(context.Background(), c, appID, addresses)
+}
 
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, addresses []string) error {
 	req := api.Allowlist{Addresses: addresses}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	_, reqErr := c.Request("DELETE", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	return nil
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/allowlist/", appID), req)
 }
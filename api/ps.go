@@ -34,6 +34,12 @@ type Command struct {
 	Command []string `json:"command"`
 }
 
+// TerminalSize is the size of the terminal of a command run with Tty, in characters.
+type TerminalSize struct {
+	Width  uint16 `json:"Width"`
+	Height uint16 `json:"Height"`
+}
+
 // PodType holds pods of the same type.
 type PodType struct {
 	Ptype    string
@@ -43,6 +49,12 @@ type PodType struct {
 // PodTypes holds groups of pods organized by type.
 type PodTypes []PodType
 
+// PodPortForwardRequest is the definition of websocket
+// /v2/apps/<app id>/pods/<pod id>/portforward/.
+type PodPortForwardRequest struct {
+	Port int `json:"port"`
+}
+
 // PodLogsRequest is the definition of websocket /v2/apps/<app id>/logs
 type PodLogsRequest struct {
 	Lines     int    `json:"lines"`
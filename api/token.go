@@ -9,3 +9,18 @@ type Token struct {
 	Created string `json:"created"`
 	Updated string `json:"updated"`
 }
+
+// TokenCreateRequest is the definition of POST /v2/tokens/.
+type TokenCreateRequest struct {
+	Alias string `json:"alias,omitempty"`
+}
+
+// TokenCreateResponse is the definition of a token created with POST /v2/tokens/. It is
+// the only response holding the key of the token.
+type TokenCreateResponse struct {
+	UUID    string `json:"uuid"`
+	Owner   string `json:"owner"`
+	Alias   string `json:"alias"`
+	Token   string `json:"token"`
+	Created string `json:"created"`
+}
@@ -46,3 +46,8 @@ type UserApps struct {
 	Username string   `json:"username"`
 	Apps     []string `json:"apps"`
 }
+
+// AdminPermRequest is the definition of POST /v2/admin/perms/.
+type AdminPermRequest struct {
+	Username string `json:"username"`
+}
@@ -2,8 +2,9 @@
 package apps
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,18 +12,18 @@ import (
 
 // List lists apps on a Drycc controller.
 func List(c *drycc.Client, workspace string, results int) (api.Apps, int, error) {
-	body, count, reqErr := c.LimitedRequest(fmt.Sprintf("/v2/apps/?workspace=%s", workspace), results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.App{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, workspace, results)
+}
 
-	var apps []api.App
-	if err := json.Unmarshal([]byte(body), &apps); err != nil {
-		return []api.App{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, workspace string, results int) (api.Apps, int, error) {
+	return drycc.List[api.App](ctx, c, fmt.Sprintf("/v2/apps/?workspace=%s", workspace), results)
+}
 
-	return apps, count, reqErr
+// All returns an iterator over every app in a workspace, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, workspace string, pageSize int) iter.Seq2[api.App, error] {
+	return drycc.All[api.App](ctx, c, fmt.Sprintf("/v2/apps/?workspace=%s", workspace), pageSize)
 }
 
 // New creates a new app with the given appID and workspace.
@@ -30,97 +31,59 @@ func List(c *drycc.Client, workspace string, results int) (api.Apps, int, error)
 //
 // If the app name already exists, the error drycc.ErrDuplicateApp will be returned.
 func New(c *drycc.Client, appID string, workspace string) (api.App, error) {
-	body := []byte{}
+	return NewWithContext(context.Background(), c, appID, workspace)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID string, workspace string) (api.App, error) {
 	req := api.AppCreateRequest{ID: appID, Workspace: workspace}
-	b, err := json.Marshal(req)
-	if err != nil {
-		return api.App{}, err
-	}
-	body = b
-
-	res, reqErr := c.Request("POST", "/v2/apps/", body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.App{}, reqErr
-	}
-	defer res.Body.Close()
-
-	app := api.App{}
-	if err := json.NewDecoder(res.Body).Decode(&app); err != nil {
-		return api.App{}, err
-	}
-
-	return app, reqErr
+	return drycc.Post[api.App](ctx, c, "/v2/apps/", req)
 }
 
 // Get app details from a controller.
 func Get(c *drycc.Client, appID string) (api.App, error) {
-	u := fmt.Sprintf("/v2/apps/%s/", appID)
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.App{}, reqErr
-	}
-	defer res.Body.Close()
-
-	app := api.App{}
-
-	if err := json.NewDecoder(res.Body).Decode(&app); err != nil {
-		return api.App{}, err
-	}
+	return GetWithContext(context.Background(), c, appID)
+}
 
-	return app, reqErr
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string) (api.App, error) {
+	return drycc.Get[api.App](ctx, c, fmt.Sprintf("/v2/apps/%s/", appID))
 }
 
 // Run a one-time command in your app. This will start a kubernetes job with the
 // same container image and environment as the rest of the app.
 func Run(c *drycc.Client, appID string, command string, volumes map[string]any, timeout, expires uint32) error {
+	return RunWithContext(context.Background(), c, appID, command, volumes, timeout, expires)
+}
+
+// RunWithContext is like [Run] but carries ctx.
+func RunWithContext(ctx context.Context, c *drycc.Client, appID string, command string, volumes map[string]any, timeout, expires uint32) error {
 	req := api.AppRunRequest{
 		Command: command,
 		Volumes: volumes,
 		Timeout: timeout,
 		Expires: expires,
 	}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	u := fmt.Sprintf("/v2/apps/%s/run", appID)
-
-	res, reqErr := c.Request("POST", u, body)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-	return reqErr
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/run", appID), req)
 }
 
 // Delete an app.
 func Delete(c *drycc.Client, appID string) error {
-	u := fmt.Sprintf("/v2/apps/%s/", appID)
+	return DeleteWithContext(context.Background(), c, appID)
+}
 
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/", appID), nil)
 }
 
 // Transfer moves an app to another workspace.
 func Transfer(c *drycc.Client, appID string, workspace string) error {
-	u := fmt.Sprintf("/v2/apps/%s/", appID)
+	return TransferWithContext(context.Background(), c, appID, workspace)
+}
 
+// TransferWithContext is like [Transfer] but carries ctx.
+func TransferWithContext(ctx context.Context, c *drycc.Client, appID string, workspace string) error {
 	req := api.AppUpdateRequest{Workspace: workspace}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, err := c.Request("PATCH", u, body)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return drycc.Send(ctx, c, "PATCH", fmt.Sprintf("/v2/apps/%s/", appID), req)
 }
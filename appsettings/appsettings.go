@@ -2,7 +2,7 @@
 package appsettings
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -11,20 +11,12 @@ import (
 
 // List lists an app's settings.
 func List(c *drycc.Client, app string) (api.AppSettings, error) {
-	u := fmt.Sprintf("/v2/apps/%s/settings/", app)
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil {
-		return api.AppSettings{}, reqErr
-	}
-	defer res.Body.Close()
-
-	settings := api.AppSettings{}
-	if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
-		return api.AppSettings{}, err
-	}
+	return ListWithContext(context.Background(), c, app)
+}
 
-	return settings, reqErr
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, app string) (api.AppSettings, error) {
+	return drycc.Get[api.AppSettings](ctx, c, fmt.Sprintf("/v2/apps/%s/settings/", app))
 }
 
 // Set sets an app's settings variables.
@@ -37,23 +29,10 @@ func List(c *drycc.Client, app string) (api.AppSettings, error) {
 //
 // Calling Set() with an empty api.AppSettings will return a drycc.ErrConflict.
 func Set(c *drycc.Client, app string, appSettings api.AppSettings) (api.AppSettings, error) {
-	body, err := json.Marshal(appSettings)
-	if err != nil {
-		return api.AppSettings{}, err
-	}
-
-	u := fmt.Sprintf("/v2/apps/%s/settings/", app)
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil {
-		return api.AppSettings{}, reqErr
-	}
-	defer res.Body.Close()
-
-	newAppSettings := api.AppSettings{}
-	if err = json.NewDecoder(res.Body).Decode(&newAppSettings); err != nil {
-		return api.AppSettings{}, err
-	}
+	return SetWithContext(context.Background(), c, app, appSettings)
+}
 
-	return newAppSettings, reqErr
+// SetWithContext is like [Set] but carries ctx.
+func SetWithContext(ctx context.Context, c *drycc.Client, app string, appSettings api.AppSettings) (api.AppSettings, error) {
+	return drycc.Post[api.AppSettings](ctx, c, fmt.Sprintf("/v2/apps/%s/settings/", app), appSettings)
 }
@@ -2,9 +2,9 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -12,9 +12,11 @@ import (
 
 // Login to the controller and get a oauth url
 func Login(c *drycc.Client, username, password string) (string, error) {
-	c.HTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error {
-		return http.ErrUseLastResponse
-	}
+	return LoginWithContext(context.Background(), c, username, password)
+}
+
+// LoginWithContext is like [Login] but carries ctx.
+func LoginWithContext(ctx context.Context, c *drycc.Client, username, password string) (string, error) {
 	var err error
 	var body []byte
 	if username != "" && password != "" {
@@ -26,8 +28,9 @@ func Login(c *drycc.Client, username, password string) (string, error) {
 		body = nil
 	}
 
-	res, err := c.Request("POST", "/v2/auth/login/", body)
-	if err != nil && !drycc.IsErrAPIMismatch(err) {
+	// The login URL is returned in the Location header of a redirect.
+	res, err := c.RequestWithContext(drycc.WithoutRedirects(ctx), "POST", "/v2/auth/login/", body)
+	if err != nil {
 		return "", err
 	}
 	defer res.Body.Close()
@@ -39,37 +42,41 @@ func Login(c *drycc.Client, username, password string) (string, error) {
 		return login.Key, nil
 	}
 
-	url := res.Header.Get("Location")
-	return url, err
+	return res.Header.Get("Location"), nil
 }
 
 // Token to the controller and get a token
 func Token(c *drycc.Client, key, alias string) (api.AuthTokenResponse, error) {
-	path := fmt.Sprintf("/v2/auth/token/%s/?alias=%s", key, alias)
-	res, reqErr := c.Request("GET", path, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.AuthTokenResponse{}, reqErr
-	}
-	defer res.Body.Close()
-	token := api.AuthTokenResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
-		return api.AuthTokenResponse{}, err
+	return TokenWithContext(context.Background(), c, key, alias)
+}
+
+// TokenWithContext is like [Token] but carries ctx.
+func TokenWithContext(ctx context.Context, c *drycc.Client, key, alias string) (api.AuthTokenResponse, error) {
+	return drycc.Get[api.AuthTokenResponse](ctx, c, fmt.Sprintf("/v2/auth/token/%s/?alias=%s", key, alias))
+}
+
+// Save sets the token of a login on c and stores it in store, under the controller of c
+// and the user the token belongs to, so that it can be found again with store.Get.
+func Save(store drycc.CredentialStore, c *drycc.Client, token api.AuthTokenResponse) error {
+	if err := store.Set(c.ControllerURL.String(), token.Username, token.Token); err != nil {
+		return err
 	}
-	return token, reqErr
+	c.SetToken(token.Token)
+	return nil
+}
+
+// Forget removes the token of a user of the controller of c from store, for example when
+// logging out.
+func Forget(store drycc.CredentialStore, c *drycc.Client, username string) error {
+	return store.Delete(c.ControllerURL.String(), username)
 }
 
 // Whoami retrives the user object for the authenticated user.
 func Whoami(c *drycc.Client) (api.User, error) {
-	res, err := c.Request("GET", "/v2/auth/whoami/", nil)
-	if err != nil {
-		return api.User{}, err
-	}
-	defer res.Body.Close()
-
-	resUser := api.User{}
-	if err = json.NewDecoder(res.Body).Decode(&resUser); err != nil {
-		return api.User{}, err
-	}
+	return WhoamiWithContext(context.Background(), c)
+}
 
-	return resUser, nil
+// WhoamiWithContext is like [Whoami] but carries ctx.
+func WhoamiWithContext(ctx context.Context, c *drycc.Client) (api.User, error) {
+	return drycc.Get[api.User](ctx, c, "/v2/auth/whoami/")
 }
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -101,3 +102,37 @@ func TestToken(t *testing.T) {
 		t.Errorf("Expected %s, Got %s", expected, token)
 	}
 }
+
+func TestSave(t *testing.T) {
+	t.Parallel()
+
+	client, err := drycc.New(false, "drycc.example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := drycc.NewMemoryStore()
+
+	// Saving is safe while requests read the token.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.CurrentToken()
+	}()
+	if err := Save(store, client, api.AuthTokenResponse{Username: "test", Token: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+	if client.CurrentToken() != "abc" {
+		t.Errorf("Expected abc, Got %s", client.CurrentToken())
+	}
+	if token, err := store.Get("drycc.example.com", "test"); err != nil || token != "abc" {
+		t.Errorf("Expected abc, Got %s (%v)", token, err)
+	}
+
+	if err := Forget(store, client, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("drycc.example.com", "test"); !errors.Is(err, drycc.ErrNoCredential) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrNoCredential, err)
+	}
+}
@@ -0,0 +1,265 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// DefaultLoginTimeout is how long LoginInteractive waits for the user to log in, unless
+// changed with WithLoginTimeout.
+const DefaultLoginTimeout = 5 * time.Minute
+
+// Browser opens url in a web browser.
+type Browser func(url string) error
+
+// OpenBrowser opens url in the default web browser of the system.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// InteractiveOption configures LoginInteractive.
+type InteractiveOption func(*interactiveOptions)
+
+type interactiveOptions struct {
+	alias        string
+	browser      Browser
+	out          io.Writer
+	loopback     bool
+	pollInterval time.Duration
+	timeout      time.Duration
+	store        drycc.CredentialStore
+}
+
+// WithAlias names the token created by the login.
+func WithAlias(alias string) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.alias = alias
+	}
+}
+
+// WithBrowser opens the login page with browser instead of OpenBrowser. A nil browser
+// only prints the URL of the page, which suits machines without a display.
+func WithBrowser(browser Browser) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.browser = browser
+	}
+}
+
+// WithOutput prints the URL of the login page to w instead of os.Stderr.
+func WithOutput(w io.Writer) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.out = w
+	}
+}
+
+// WithLoopback asks the controller to send the browser to a listener on the loopback
+// interface once the user has logged in, so that the login completes without waiting for
+// the next poll. Controllers that don't support it are polled as usual.
+func WithLoopback() InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.loopback = true
+	}
+}
+
+// WithPollInterval sets how often the controller is asked whether the user has logged
+// in. It defaults to one second, which a non-positive interval keeps.
+func WithPollInterval(d time.Duration) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.pollInterval = d
+	}
+}
+
+// WithLoginTimeout limits the time the user has to log in. A zero timeout means no limit
+// other than the context.
+func WithLoginTimeout(d time.Duration) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.timeout = d
+	}
+}
+
+// WithStore saves the token of the login in store and sets it on the client, as Save
+// does.
+func WithStore(store drycc.CredentialStore) InteractiveOption {
+	return func(o *interactiveOptions) {
+		o.store = store
+	}
+}
+
+// LoginInteractive logs in with a browser. The login page of the controller is opened, and
+// its URL printed, then the controller is polled until the user has logged in and the
+// token of the login is returned. The token is ready to be set on the client.
+func LoginInteractive(c *drycc.Client, opts ...InteractiveOption) (api.AuthTokenResponse, error) {
+	return LoginInteractiveWithContext(context.Background(), c, opts...)
+}
+
+// LoginInteractiveWithContext is like [LoginInteractive] but carries ctx.
+func LoginInteractiveWithContext(ctx context.Context, c *drycc.Client, opts ...InteractiveOption) (api.AuthTokenResponse, error) {
+	o := interactiveOptions{
+		browser:      OpenBrowser,
+		out:          os.Stderr,
+		pollInterval: time.Second,
+		timeout:      DefaultLoginTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pollInterval <= 0 {
+		o.pollInterval = time.Second
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	path := "/v2/auth/login/"
+	var cb *callback
+	if o.loopback {
+		var err error
+		if cb, err = listenCallback(); err != nil {
+			return api.AuthTokenResponse{}, err
+		}
+		defer cb.Close()
+		path += "?redirect_uri=" + url.QueryEscape(cb.url)
+	}
+
+	loginURL, key, err := startLogin(ctx, c, path)
+	if err != nil {
+		return api.AuthTokenResponse{}, err
+	}
+	if cb != nil {
+		cb.expect(key)
+	}
+
+	fmt.Fprintf(o.out, "Log in with your browser at %s\n", loginURL)
+	if o.browser != nil {
+		if err := o.browser(loginURL); err != nil {
+			fmt.Fprintf(o.out, "Couldn't open a browser (%v), open the URL yourself.\n", err)
+		}
+	}
+
+	var done <-chan struct{}
+	if cb != nil {
+		done = cb.done
+	}
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	for {
+		// The controller answers 404 until the user has logged in.
+		token, err := TokenWithContext(ctx, c, key, o.alias)
+		if err == nil && o.store != nil {
+			err = Save(o.store, c, token)
+		}
+		if !errors.As(err, &drycc.ErrNotFound{}) {
+			return token, err
+		}
+		select {
+		case <-ctx.Done():
+			return api.AuthTokenResponse{}, ctx.Err()
+		case <-ticker.C:
+		case <-done:
+			done = nil
+		}
+	}
+}
+
+// startLogin starts a login without credentials, returning the absolute URL of the login
+// page and the key to exchange for a token.
+func startLogin(ctx context.Context, c *drycc.Client, path string) (string, string, error) {
+	// The login URL is returned in the Location header of a redirect.
+	res, err := c.RequestWithContext(drycc.WithoutRedirects(ctx), "POST", path, nil)
+	if err != nil {
+		return "", "", err
+	}
+	res.Body.Close()
+
+	location := res.Header.Get("Location")
+	if location == "" {
+		return "", "", errors.New("the controller didn't return a login URL")
+	}
+	u, err := c.ControllerURL.Parse(location)
+	if err != nil {
+		return "", "", err
+	}
+	// Some controllers append more parameters to the key after a slash.
+	key, _, _ := strings.Cut(u.Query().Get("key"), "/")
+	if key == "" {
+		return "", "", fmt.Errorf("no key in the login URL %s", location)
+	}
+	return u.String(), key, nil
+}
+
+// callback is a listener on the loopback interface the browser is sent to once the user
+// has logged in.
+type callback struct {
+	url    string
+	server *http.Server
+	done   chan struct{}
+
+	mu   sync.Mutex
+	key  string
+	once sync.Once
+}
+
+func listenCallback() (*callback, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	cb := &callback{url: fmt.Sprintf("http://%s/callback", l.Addr()), done: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /callback", cb.serveHTTP)
+	cb.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go cb.server.Serve(l)
+	return cb, nil
+}
+
+// expect sets the key of the login the callback waits for.
+func (cb *callback) expect(key string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.key = key
+}
+
+func (cb *callback) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	cb.mu.Lock()
+	key := cb.key
+	cb.mu.Unlock()
+	if key == "" || r.URL.Query().Get("key") != key {
+		http.Error(w, "Unknown login.", http.StatusBadRequest)
+		return
+	}
+	cb.once.Do(func() { close(cb.done) })
+	fmt.Fprintln(w, "Logged in, you may close this window.")
+}
+
+func (cb *callback) Close() error {
+	return cb.server.Close()
+}
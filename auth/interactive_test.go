@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/drycctest"
+)
+
+// logIn plays the user logging in on the page at url.
+func logIn(url string) error {
+	res, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.New(res.Status)
+	}
+	return nil
+}
+
+func newInteractiveClient(t *testing.T) (*drycctest.Server, *drycc.Client) {
+	server := drycctest.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := drycc.New(false, server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+func TestLoginInteractive(t *testing.T) {
+	t.Parallel()
+
+	server, client := newInteractiveClient(t)
+	store := drycc.NewMemoryStore()
+
+	var opened string
+	out := &bytes.Buffer{}
+	token, err := LoginInteractive(client, WithOutput(out), WithAlias("test"), WithStore(store),
+		WithBrowser(func(url string) error {
+			opened = url
+			return logIn(url)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := api.AuthTokenResponse{Token: server.Token(), Username: drycctest.DefaultUsername}
+	if token != expected {
+		t.Errorf("Expected %v, Got %v", expected, token)
+	}
+
+	if client.Token != server.Token() {
+		t.Errorf("Expected the token to be set on the client, Got %s", client.Token)
+	}
+	if stored, err := store.Get(server.URL, drycctest.DefaultUsername); err != nil || stored != server.Token() {
+		t.Errorf("Expected %s to be stored, Got %s (%v)", server.Token(), stored, err)
+	}
+
+	if !strings.HasPrefix(opened, server.URL+"/v2/login/drycc/?key=") {
+		t.Errorf("Expected a login URL of %s, Got %s", server.URL, opened)
+	}
+	if !strings.Contains(out.String(), opened) {
+		t.Errorf("Expected %s to be printed, Got %s", opened, out.String())
+	}
+}
+
+func TestLoginInteractiveLoopback(t *testing.T) {
+	t.Parallel()
+
+	server, client := newInteractiveClient(t)
+
+	// Polling is too slow for the login to complete, so the callback must wake it up.
+	errs := make(chan error, 1)
+	token, err := LoginInteractive(client, WithOutput(&bytes.Buffer{}), WithLoopback(),
+		WithPollInterval(time.Hour), WithLoginTimeout(10*time.Second),
+		WithBrowser(func(url string) error {
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				errs <- logIn(url)
+			}()
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Error(err)
+	}
+
+	if token.Token != server.Token() {
+		t.Errorf("Expected %s, Got %s", server.Token(), token.Token)
+	}
+}
+
+func TestLoginInteractiveBrowserError(t *testing.T) {
+	t.Parallel()
+
+	server, client := newInteractiveClient(t)
+
+	out := &bytes.Buffer{}
+	token, err := LoginInteractive(client, WithOutput(out), WithPollInterval(10*time.Millisecond),
+		WithBrowser(func(url string) error {
+			go logIn(url)
+			return errors.New("no display")
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.Token != server.Token() {
+		t.Errorf("Expected %s, Got %s", server.Token(), token.Token)
+	}
+	if !strings.Contains(out.String(), "no display") {
+		t.Errorf("Expected the browser error to be printed, Got %s", out.String())
+	}
+}
+
+func TestLoginInteractiveTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, client := newInteractiveClient(t)
+
+	_, err := LoginInteractive(client, WithOutput(&bytes.Buffer{}), WithBrowser(nil),
+		WithPollInterval(10*time.Millisecond), WithLoginTimeout(100*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+	// A zero interval falls back to the default.
+	_, err = LoginInteractive(client, WithOutput(&bytes.Buffer{}), WithBrowser(nil),
+		WithPollInterval(0), WithLoginTimeout(100*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+}
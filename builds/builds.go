@@ -2,7 +2,7 @@
 package builds
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -11,52 +11,34 @@ import (
 
 // Get a build of an app.
 func Get(c *drycc.Client, appID string, version int) (api.Build, error) {
+	return GetWithContext(context.Background(), c, appID, version)
+}
+
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string, version int) (api.Build, error) {
 	u := fmt.Sprintf("/v2/apps/%s/build/", appID)
 	if version > 0 {
 		u = fmt.Sprintf("%s?version=v%d", u, version)
 	}
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Build{}, reqErr
-	}
-	defer res.Body.Close()
-
-	var build api.Build
-	if err := json.NewDecoder(res.Body).Decode(&build); err != nil {
-		return api.Build{}, err
-	}
-	return build, reqErr
+	return drycc.Get[api.Build](ctx, c, u)
 }
 
 // New a build of an app.
 func New(c *drycc.Client, appID string, image string, stack string,
 	procfile map[string]string, dryccfile map[string]any,
 ) (api.Build, error) {
-	u := fmt.Sprintf("/v2/apps/%s/build/", appID)
+	return NewWithContext(context.Background(), c, appID, image, stack, procfile, dryccfile)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID string, image string, stack string,
+	procfile map[string]string, dryccfile map[string]any,
+) (api.Build, error) {
 	req := api.CreateBuildRequest{
 		Image:     image,
 		Stack:     stack,
 		Procfile:  procfile,
 		Dryccfile: dryccfile,
 	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Build{}, err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Build{}, reqErr
-	}
-	defer res.Body.Close()
-
-	build := api.Build{}
-	if err = json.NewDecoder(res.Body).Decode(&build); err != nil {
-		return api.Build{}, err
-	}
-
-	return build, reqErr
+	return drycc.Post[api.Build](ctx, c, fmt.Sprintf("/v2/apps/%s/build/", appID), req)
 }
@@ -0,0 +1,143 @@
+// Package cassette records the HTTP conversations of a drycc.Client with a controller to a
+// file, and replays them later without a controller, for example in CI:
+//
+//	recorder, err := cassette.New("testdata/apps.json", cassette.ModeReplay)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//
+//	client, err := drycc.New(true, "https://drycc.example.com", "token", drycc.WithTransport(recorder))
+//
+// Credentials are scrubbed before they are written: tokens, service keys, passwords,
+// private keys, ACME issuer secrets and the credentials of bound resources.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Redacted replaces scrubbed credentials in a cassette.
+const Redacted = "REDACTED"
+
+// Cassette is the list of interactions recorded in a file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response the controller sent back.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err = json.Unmarshal(contents, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save writes the cassette to a file, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0o644)
+}
+
+var (
+	scrubbedHeaders = []string{"Authorization", "X-Drycc-Service-Key", "Cookie", "Set-Cookie"}
+	// scrubbedFields are the JSON fields holding credentials, wherever they appear.
+	scrubbedFields = map[string]bool{"token": true, "key": true, "password": true, "key_secret": true}
+)
+
+// scrubHeader returns a copy of header with credentials redacted.
+func scrubHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range scrubbedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, Redacted)
+		}
+	}
+	return header
+}
+
+// scrubBody redacts credentials from a JSON body. Other bodies are returned unchanged,
+// as are bodies without credentials, so that they keep their formatting.
+func scrubBody(body []byte) []byte {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if d.Decode(&v) != nil || !scrubValue(v) {
+		return body
+	}
+	scrubbed, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+// scrubValue redacts credentials in a decoded JSON value, reporting whether it changed.
+func scrubValue(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for field, value := range v {
+			switch {
+			case scrubbedFields[field]:
+				if s, ok := value.(string); ok && s != "" && s != Redacted {
+					v[field] = Redacted
+					changed = true
+				}
+			case field == "data":
+				// The data of a resource holds the credentials of its service.
+				if data, ok := value.(map[string]any); ok {
+					for name := range data {
+						if data[name] != Redacted {
+							data[name] = Redacted
+							changed = true
+						}
+					}
+				}
+			default:
+				changed = scrubValue(value) || changed
+			}
+		}
+	case []any:
+		for _, value := range v {
+			changed = scrubValue(value) || changed
+		}
+	}
+	return changed
+}
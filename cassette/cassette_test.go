@@ -0,0 +1,81 @@
+package cassette
+
+import (
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScrubBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{`{"token":"abc"}`, `{"token":"REDACTED"}`},
+		{`{"username":"test","password":"secret"}`, `{"password":"REDACTED","username":"test"}`},
+		{
+			`{"issuer":{"email":"a@example.com","key_id":"id","key_secret":"secret"}}`,
+			`{"issuer":{"email":"a@example.com","key_id":"id","key_secret":"REDACTED"}}`,
+		},
+		{
+			`{"results":[{"name":"db","data":{"password":"secret","port":5432}}]}`,
+			`{"results":[{"data":{"password":"REDACTED","port":"REDACTED"},"name":"db"}]}`,
+		},
+		// Bodies without credentials keep their formatting.
+		{`{ "id": "example-go", "count": 1.50 }`, `{ "id": "example-go", "count": 1.50 }`},
+		{`not json`, `not json`},
+	}
+
+	for _, test := range tests {
+		if actual := string(scrubBody([]byte(test.body))); actual != test.expected {
+			t.Errorf("Expected %s, Got %s", test.expected, actual)
+		}
+	}
+}
+
+func TestScrubHeader(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Set("Authorization", "token abc")
+	header.Set("X-Drycc-Service-Key", "secret")
+	header.Set("Content-Type", "application/json")
+
+	scrubbed := scrubHeader(header)
+
+	expected := http.Header{
+		"Authorization":       {Redacted},
+		"X-Drycc-Service-Key": {Redacted},
+		"Content-Type":        {"application/json"},
+	}
+	if !reflect.DeepEqual(expected, scrubbed) {
+		t.Errorf("Expected %v, Got %v", expected, scrubbed)
+	}
+	if header.Get("Authorization") != "token abc" {
+		t.Error("Expected the original header to be left untouched")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	expected := &Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: "GET", URL: "http://localhost/v2/apps/"},
+		Response: Response{StatusCode: 200, Body: `{"count":0}`},
+	}}}
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	if err := expected.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	actual, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+}
@@ -0,0 +1,275 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ErrUnmatched is returned by a strict replaying Recorder for requests that don't match
+// any recorded interaction.
+var ErrUnmatched = errors.New("cassette: no recorded interaction matches the request")
+
+// Mode tells a Recorder whether to record or replay interactions.
+type Mode int
+
+const (
+	// ModeRecord sends requests to the controller and records them.
+	ModeRecord Mode = iota
+	// ModeReplay answers requests with recorded responses.
+	ModeReplay
+)
+
+// Match selects the parts of a request compared with recorded ones when replaying.
+type Match uint
+
+const (
+	// MatchMethod compares the HTTP methods.
+	MatchMethod Match = 1 << iota
+	// MatchPath compares the URL paths. The scheme and host are never compared, so that
+	// cassettes can be replayed against any controller URL.
+	MatchPath
+	// MatchQuery compares the query parameters, regardless of their order.
+	MatchQuery
+	// MatchBody compares the bodies, as JSON values when both are JSON.
+	MatchBody
+
+	// MatchDefault compares the methods, paths and query parameters.
+	MatchDefault = MatchMethod | MatchPath | MatchQuery
+	// MatchAll compares everything a request is matched on.
+	MatchAll = MatchDefault | MatchBody
+)
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithMatch sets the parts of requests compared when replaying. It defaults to
+// MatchDefault.
+func WithMatch(match Match) Option {
+	return func(r *Recorder) {
+		r.match = match
+	}
+}
+
+// WithStrict makes a replaying Recorder fail requests with ErrUnmatched unless they match
+// an interaction that wasn't replayed yet. Otherwise, interactions can be replayed more
+// than once and unmatched requests are sent to the transport.
+func WithStrict(strict bool) Option {
+	return func(r *Recorder) {
+		r.strict = strict
+	}
+}
+
+// WithTransport sets the transport requests are sent through when recording, or when
+// they don't match while replaying. It defaults to [http.DefaultTransport].
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// Recorder is an [http.RoundTripper] recording or replaying interactions. It is safe for
+// concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	match     Match
+	strict    bool
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// New returns a recorder for the cassette at path. In ModeReplay the cassette is loaded
+// from path, while in ModeRecord it is written there by Stop.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		match:     MatchDefault,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.replayed = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Stop writes the recorded interactions to the cassette file. It does nothing when
+// replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Cassette returns the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		if res := r.replay(req, body); res != nil {
+			return res, nil
+		}
+		if r.strict {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnmatched, req.Method, req.URL.RequestURI())
+		}
+		return r.transport.RoundTrip(req)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: scrubHeader(req.Header),
+			Body:   string(scrubBody(body)),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     scrubHeader(res.Header),
+			Body:       string(scrubBody(resBody)),
+		},
+	}
+	// The scrubbed body may not have the recorded length.
+	interaction.Response.Header.Del("Content-Length")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return res, nil
+}
+
+// replay returns the response of the first interaction matching req, preferring the ones
+// not replayed yet, or nil if there is none.
+func (r *Recorder) replay(req *http.Request, body []byte) *http.Response {
+	// Recorded bodies are scrubbed, so the request must be too to compare them.
+	body = scrubBody(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !r.matches(req, body, interaction.Request) {
+			continue
+		}
+		if !r.replayed[i] {
+			found = i
+			break
+		}
+		if found < 0 && !r.strict {
+			found = i
+		}
+	}
+	if found < 0 {
+		return nil
+	}
+	r.replayed[found] = true
+
+	recorded := r.cassette.Interactions[found].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(recorded.Body)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+func (r *Recorder) matches(req *http.Request, body []byte, recorded Request) bool {
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if r.match&MatchMethod != 0 && req.Method != recorded.Method {
+		return false
+	}
+	if r.match&MatchPath != 0 && req.URL.Path != u.Path {
+		return false
+	}
+	if r.match&MatchQuery != 0 && !reflect.DeepEqual(req.URL.Query(), u.Query()) {
+		return false
+	}
+	if r.match&MatchBody != 0 && !equalBodies(body, []byte(recorded.Body)) {
+		return false
+	}
+	return true
+}
+
+// equalBodies compares two bodies as JSON values if they both are JSON, or as bytes.
+func equalBodies(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) == nil && json.Unmarshal(b, &vb) == nil {
+		return reflect.DeepEqual(va, vb)
+	}
+	return bytes.Equal(a, b)
+}
+
+// readBody reads the body of req and rewinds it, so that it can still be sent.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,204 @@
+package cassette_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/builds"
+	"github.com/drycc/controller-sdk-go/cassette"
+	"github.com/drycc/controller-sdk-go/drycctest"
+	"github.com/drycc/controller-sdk-go/resources"
+)
+
+// record records a conversation with a fake controller and returns the cassette path.
+func record(t *testing.T) string {
+	t.Helper()
+
+	server := drycctest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := server.Client(drycc.WithTransport(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.ServiceKey = "service-key"
+
+	if _, err = apps.New(client, "example-go", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = builds.New(client, "example-go", "example/go:v1", "container", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = builds.New(client, "example-go", "example/go:v2", "container", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = resources.Create(client, "example-go", api.Resource{Name: "db", Plan: "postgresql:standard-10"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = resources.Binding(client, "example-go", "db", api.ResourceBinding{BindAction: "bind"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = apps.List(client, "", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// replay returns a client replaying the cassette at path.
+func replay(t *testing.T, path string, opts ...cassette.Option) *drycc.Client {
+	t.Helper()
+
+	recorder, err := cassette.New(path, cassette.ModeReplay, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := drycc.New(true, "https://drycc.example.com", "another-token", drycc.WithTransport(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestRecordScrubsCredentials(t *testing.T) {
+	t.Parallel()
+
+	path := record(t)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{drycctest.DefaultToken, "service-key"} {
+		if strings.Contains(string(contents), secret) {
+			t.Errorf("Expected %s to be scrubbed from the cassette", secret)
+		}
+	}
+
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 6 {
+		t.Fatalf("Expected %d, Got %d", 6, len(c.Interactions))
+	}
+	if body := c.Interactions[4].Response.Body; !strings.Contains(body, `"password":"REDACTED"`) {
+		t.Errorf("Expected the resource password to be scrubbed, Got %s", body)
+	}
+	if auth := c.Interactions[0].Request.Header.Get("Authorization"); auth != cassette.Redacted {
+		t.Errorf("Expected %s, Got %s", cassette.Redacted, auth)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	client := replay(t, record(t))
+
+	list, count, err := apps.List(client, "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || list[0].ID != "example-go" {
+		t.Errorf("Expected example-go, Got %v", list)
+	}
+	resource, err := resources.Binding(client, "example-go", "db", api.ResourceBinding{BindAction: "bind"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resource.Data["password"] != cassette.Redacted {
+		t.Errorf("Expected %s, Got %v", cassette.Redacted, resource.Data["password"])
+	}
+
+	// Without body matching, both builds match; they are replayed in order.
+	for _, expected := range []string{"example/go:v1", "example/go:v2"} {
+		build, err := builds.New(client, "example-go", "whatever", "container", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if build.Image != expected {
+			t.Errorf("Expected %s, Got %s", expected, build.Image)
+		}
+	}
+}
+
+func TestReplayMatchBody(t *testing.T) {
+	t.Parallel()
+
+	client := replay(t, record(t), cassette.WithMatch(cassette.MatchAll), cassette.WithStrict(true))
+
+	build, err := builds.New(client, "example-go", "example/go:v2", "container", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if build.Image != "example/go:v2" {
+		t.Errorf("Expected %s, Got %s", "example/go:v2", build.Image)
+	}
+
+	if _, err = builds.New(client, "example-go", "example/go:v3", "container", nil, nil); !errors.Is(err, cassette.ErrUnmatched) {
+		t.Errorf("Expected %v, Got %v", cassette.ErrUnmatched, err)
+	}
+}
+
+func TestReplayStrict(t *testing.T) {
+	t.Parallel()
+
+	client := replay(t, record(t), cassette.WithStrict(true))
+
+	if _, _, err := apps.List(client, "", 100); err != nil {
+		t.Fatal(err)
+	}
+	// Strict recorders replay every interaction once.
+	if _, _, err := apps.List(client, "", 100); !errors.Is(err, cassette.ErrUnmatched) {
+		t.Errorf("Expected %v, Got %v", cassette.ErrUnmatched, err)
+	}
+	if _, err := apps.Get(client, "example-go"); !errors.Is(err, cassette.ErrUnmatched) {
+		t.Errorf("Expected %v, Got %v", cassette.ErrUnmatched, err)
+	}
+}
+
+func TestReplayPassthrough(t *testing.T) {
+	t.Parallel()
+
+	path := record(t)
+
+	server := drycctest.NewServer()
+	defer server.Close()
+
+	recorder, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := server.Client(drycc.WithTransport(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unmatched requests reach the controller, which has no apps.
+	if _, err = apps.Get(client, "example-go"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound, Got %v", err)
+	}
+	// Matched ones are replayed.
+	list, _, err := apps.List(client, "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"example-go"}, []string{list[0].ID}) {
+		t.Errorf("Expected example-go, Got %v", list)
+	}
+}
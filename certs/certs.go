@@ -2,8 +2,9 @@
 package certs
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,19 +12,18 @@ import (
 
 // List lists certificates added to drycc.
 func List(c *drycc.Client, appID string, results int) ([]api.Cert, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/certs/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Cert{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var res []api.Cert
-	if err := json.Unmarshal([]byte(body), &res); err != nil {
-		return []api.Cert{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) ([]api.Cert, int, error) {
+	return drycc.List[api.Cert](ctx, c, fmt.Sprintf("/v2/apps/%s/certs/", appID), results)
+}
 
-	return res, count, reqErr
+// All returns an iterator over every certificate of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Cert, error] {
+	return drycc.All[api.Cert](ctx, c, fmt.Sprintf("/v2/apps/%s/certs/", appID), pageSize)
 }
 
 // New creates a new certificate.
@@ -31,75 +31,52 @@ func List(c *drycc.Client, appID string, results int) ([]api.Cert, int, error) {
 // So to enable SSL for an app with the domain test.com, you would first create the certificate,
 // then use the attach method to attach test.com to the certificate.
 func New(c *drycc.Client, appID string, cert string, key string, name string) (api.Cert, error) {
-	req := api.CertCreateRequest{Certificate: cert, Key: key, Name: name}
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return api.Cert{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/certs/", appID)
-	res, reqErr := c.Request("POST", u, reqBody)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Cert{}, reqErr
-	}
-	defer res.Body.Close()
-
-	resCert := api.Cert{}
-	if err = json.NewDecoder(res.Body).Decode(&resCert); err != nil {
-		return api.Cert{}, err
-	}
+	return NewWithContext(context.Background(), c, appID, cert, key, name)
+}
 
-	return resCert, reqErr
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID string, cert string, key string, name string) (api.Cert, error) {
+	req := api.CertCreateRequest{Certificate: cert, Key: key, Name: name}
+	return drycc.Post[api.Cert](ctx, c, fmt.Sprintf("/v2/apps/%s/certs/", appID), req)
 }
 
 // Get retrieves information about a certificate
 func Get(c *drycc.Client, appID string, name string) (api.Cert, error) {
-	url := fmt.Sprintf("/v2/apps/%s/certs/%s", appID, name)
-	res, reqErr := c.Request("GET", url, nil)
-	if reqErr != nil {
-		return api.Cert{}, reqErr
-	}
-	defer res.Body.Close()
-
-	resCert := api.Cert{}
-	if err := json.NewDecoder(res.Body).Decode(&resCert); err != nil {
-		return api.Cert{}, err
-	}
+	return GetWithContext(context.Background(), c, appID, name)
+}
 
-	return resCert, reqErr
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string, name string) (api.Cert, error) {
+	return drycc.Get[api.Cert](ctx, c, fmt.Sprintf("/v2/apps/%s/certs/%s", appID, name))
 }
 
 // Delete removes a certificate.
 func Delete(c *drycc.Client, appID string, name string) error {
-	url := fmt.Sprintf("/v2/apps/%s/certs/%s", appID, name)
-	res, err := c.Request("DELETE", url, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, appID, name)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, name string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/certs/%s", appID, name), nil)
 }
 
 // Attach adds a domain to a certificate.
 func Attach(c *drycc.Client, appID string, name string, domain string) error {
-	req := api.CertAttachRequest{Domain: domain}
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
+	return AttachWithContext(context.Background(), c, appID, name, domain)
+}
 
-	url := fmt.Sprintf("/v2/apps/%s/certs/%s/domain/", appID, name)
-	res, err := c.Request("POST", url, reqBody)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+// AttachWithContext is like [Attach] but carries ctx.
+func AttachWithContext(ctx context.Context, c *drycc.Client, appID string, name string, domain string) error {
+	req := api.CertAttachRequest{Domain: domain}
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/certs/%s/domain/", appID, name), req)
 }
 
 // Detach removes a domain from a certificate.
 func Detach(c *drycc.Client, appID string, name string, domain string) error {
-	url := fmt.Sprintf("/v2/apps/%s/certs/%s/domain/%s", appID, name, domain)
-	res, err := c.Request("DELETE", url, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DetachWithContext(context.Background(), c, appID, name, domain)
+}
+
+// DetachWithContext is like [Detach] but carries ctx.
+func DetachWithContext(ctx context.Context, c *drycc.Client, appID string, name string, domain string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/certs/%s/domain/%s", appID, name, domain), nil)
 }
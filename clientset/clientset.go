@@ -0,0 +1,813 @@
+// Package clientset exposes the SDK through interfaces, so that code using it can be
+// tested with fakes instead of a controller.
+//
+// Every method of a service does what the function of the same name in the matching
+// package does, through its context-aware variant. For instance, AppsService.List calls
+// [apps.ListWithContext]:
+//
+//	func Deploy(ctx context.Context, cs clientset.Interface, app, image string) error {
+//	    _, err := cs.Builds().New(ctx, app, image, "container", nil, nil)
+//	    return err
+//	}
+//
+//	err := Deploy(ctx, clientset.New(client), "example-go", "example/go:latest")
+//
+// Tests can pass the fake.Clientset of package clientset/fake instead.
+package clientset
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/allowlist"
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/appsettings"
+	"github.com/drycc/controller-sdk-go/auth"
+	"github.com/drycc/controller-sdk-go/builds"
+	"github.com/drycc/controller-sdk-go/certs"
+	"github.com/drycc/controller-sdk-go/config"
+	"github.com/drycc/controller-sdk-go/domains"
+	"github.com/drycc/controller-sdk-go/events"
+	"github.com/drycc/controller-sdk-go/gateways"
+	"github.com/drycc/controller-sdk-go/keys"
+	"github.com/drycc/controller-sdk-go/limits"
+	"github.com/drycc/controller-sdk-go/ps"
+	"github.com/drycc/controller-sdk-go/pts"
+	"github.com/drycc/controller-sdk-go/releases"
+	"github.com/drycc/controller-sdk-go/resources"
+	"github.com/drycc/controller-sdk-go/routes"
+	"github.com/drycc/controller-sdk-go/services"
+	"github.com/drycc/controller-sdk-go/tls"
+	"github.com/drycc/controller-sdk-go/tokens"
+	"github.com/drycc/controller-sdk-go/users"
+	"github.com/drycc/controller-sdk-go/volumes"
+	"github.com/drycc/controller-sdk-go/workspaces"
+	"github.com/drycc/controller-sdk-go/workspaces/invitations"
+	"github.com/drycc/controller-sdk-go/workspaces/members"
+	"golang.org/x/net/websocket"
+)
+
+// Interface gives access to every service of the SDK.
+type Interface interface {
+	Allowlist() AllowlistService
+	Apps() AppsService
+	AppSettings() AppSettingsService
+	Auth() AuthService
+	Builds() BuildsService
+	Certs() CertsService
+	Config() ConfigService
+	Domains() DomainsService
+	Events() EventsService
+	Gateways() GatewaysService
+	Keys() KeysService
+	Limits() LimitsService
+	Pods() PodsService
+	Ptypes() PtypesService
+	Releases() ReleasesService
+	Resources() ResourcesService
+	Routes() RoutesService
+	Services() ServicesService
+	TLS() TLSService
+	Tokens() TokensService
+	Users() UsersService
+	Volumes() VolumesService
+	Workspaces() WorkspacesService
+	Members() MembersService
+	Invitations() InvitationsService
+}
+
+// Clientset implements Interface with a client.
+type Clientset struct {
+	client *drycc.Client
+}
+
+var _ Interface = (*Clientset)(nil)
+
+// New returns a Clientset sending requests with c.
+func New(c *drycc.Client) *Clientset {
+	return &Clientset{client: c}
+}
+
+// Client returns the client requests are sent with.
+func (cs *Clientset) Client() *drycc.Client {
+	return cs.client
+}
+
+// Allowlist returns the AllowlistService of the client.
+func (cs *Clientset) Allowlist() AllowlistService {
+	return allowlistService{cs.client}
+}
+
+// Apps returns the AppsService of the client.
+func (cs *Clientset) Apps() AppsService {
+	return appsService{cs.client}
+}
+
+// AppSettings returns the AppSettingsService of the client.
+func (cs *Clientset) AppSettings() AppSettingsService {
+	return appSettingsService{cs.client}
+}
+
+// Auth returns the AuthService of the client.
+func (cs *Clientset) Auth() AuthService {
+	return authService{cs.client}
+}
+
+// Builds returns the BuildsService of the client.
+func (cs *Clientset) Builds() BuildsService {
+	return buildsService{cs.client}
+}
+
+// Certs returns the CertsService of the client.
+func (cs *Clientset) Certs() CertsService {
+	return certsService{cs.client}
+}
+
+// Config returns the ConfigService of the client.
+func (cs *Clientset) Config() ConfigService {
+	return configService{cs.client}
+}
+
+// Domains returns the DomainsService of the client.
+func (cs *Clientset) Domains() DomainsService {
+	return domainsService{cs.client}
+}
+
+// Events returns the EventsService of the client.
+func (cs *Clientset) Events() EventsService {
+	return eventsService{cs.client}
+}
+
+// Gateways returns the GatewaysService of the client.
+func (cs *Clientset) Gateways() GatewaysService {
+	return gatewaysService{cs.client}
+}
+
+// Keys returns the KeysService of the client.
+func (cs *Clientset) Keys() KeysService {
+	return keysService{cs.client}
+}
+
+// Limits returns the LimitsService of the client.
+func (cs *Clientset) Limits() LimitsService {
+	return limitsService{cs.client}
+}
+
+// Pods returns the PodsService of the client.
+func (cs *Clientset) Pods() PodsService {
+	return podsService{cs.client}
+}
+
+// Ptypes returns the PtypesService of the client.
+func (cs *Clientset) Ptypes() PtypesService {
+	return ptypesService{cs.client}
+}
+
+// Releases returns the ReleasesService of the client.
+func (cs *Clientset) Releases() ReleasesService {
+	return releasesService{cs.client}
+}
+
+// Resources returns the ResourcesService of the client.
+func (cs *Clientset) Resources() ResourcesService {
+	return resourcesService{cs.client}
+}
+
+// Routes returns the RoutesService of the client.
+func (cs *Clientset) Routes() RoutesService {
+	return routesService{cs.client}
+}
+
+// Services returns the ServicesService of the client.
+func (cs *Clientset) Services() ServicesService {
+	return servicesService{cs.client}
+}
+
+// TLS returns the TLSService of the client.
+func (cs *Clientset) TLS() TLSService {
+	return tlsService{cs.client}
+}
+
+// Tokens returns the TokensService of the client.
+func (cs *Clientset) Tokens() TokensService {
+	return tokensService{cs.client}
+}
+
+// Users returns the UsersService of the client.
+func (cs *Clientset) Users() UsersService {
+	return usersService{cs.client}
+}
+
+// Volumes returns the VolumesService of the client.
+func (cs *Clientset) Volumes() VolumesService {
+	return volumesService{cs.client}
+}
+
+// Workspaces returns the WorkspacesService of the client.
+func (cs *Clientset) Workspaces() WorkspacesService {
+	return workspacesService{cs.client}
+}
+
+// Members returns the MembersService of the client.
+func (cs *Clientset) Members() MembersService {
+	return membersService{cs.client}
+}
+
+// Invitations returns the InvitationsService of the client.
+func (cs *Clientset) Invitations() InvitationsService {
+	return invitationsService{cs.client}
+}
+
+type allowlistService struct{ c *drycc.Client }
+
+func (s allowlistService) List(ctx context.Context, appID string) (api.Allowlist, error) {
+	return allowlist.ListWithContext(ctx, s.c, appID)
+}
+
+func (s allowlistService) Add(ctx context.Context, appID string, addresses []string) (api.Allowlist, error) {
+	return allowlist.AddWithContext(ctx, s.c, appID, addresses)
+}
+
+func (s allowlistService) Delete(ctx context.Context, appID string, addresses []string) error {
+	return allowlist.DeleteWithContext(ctx, s.c, appID, addresses)
+}
+
+type appsService struct{ c *drycc.Client }
+
+func (s appsService) List(ctx context.Context, workspace string, results int) (api.Apps, int, error) {
+	return apps.ListWithContext(ctx, s.c, workspace, results)
+}
+
+func (s appsService) All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.App, error] {
+	return apps.All(ctx, s.c, workspace, pageSize)
+}
+
+func (s appsService) New(ctx context.Context, appID string, workspace string) (api.App, error) {
+	return apps.NewWithContext(ctx, s.c, appID, workspace)
+}
+
+func (s appsService) Get(ctx context.Context, appID string) (api.App, error) {
+	return apps.GetWithContext(ctx, s.c, appID)
+}
+
+func (s appsService) Run(ctx context.Context, appID string, command string, volumes map[string]any, timeout, expires uint32) error {
+	return apps.RunWithContext(ctx, s.c, appID, command, volumes, timeout, expires)
+}
+
+func (s appsService) Delete(ctx context.Context, appID string) error {
+	return apps.DeleteWithContext(ctx, s.c, appID)
+}
+
+func (s appsService) Transfer(ctx context.Context, appID string, workspace string) error {
+	return apps.TransferWithContext(ctx, s.c, appID, workspace)
+}
+
+type appSettingsService struct{ c *drycc.Client }
+
+func (s appSettingsService) List(ctx context.Context, app string) (api.AppSettings, error) {
+	return appsettings.ListWithContext(ctx, s.c, app)
+}
+
+func (s appSettingsService) Set(ctx context.Context, app string, appSettings api.AppSettings) (api.AppSettings, error) {
+	return appsettings.SetWithContext(ctx, s.c, app, appSettings)
+}
+
+type authService struct{ c *drycc.Client }
+
+func (s authService) Login(ctx context.Context, username, password string) (string, error) {
+	return auth.LoginWithContext(ctx, s.c, username, password)
+}
+
+func (s authService) LoginInteractive(ctx context.Context, opts ...auth.InteractiveOption) (api.AuthTokenResponse, error) {
+	return auth.LoginInteractiveWithContext(ctx, s.c, opts...)
+}
+
+func (s authService) Token(ctx context.Context, key, alias string) (api.AuthTokenResponse, error) {
+	return auth.TokenWithContext(ctx, s.c, key, alias)
+}
+
+func (s authService) Whoami(ctx context.Context) (api.User, error) {
+	return auth.WhoamiWithContext(ctx, s.c)
+}
+
+type buildsService struct{ c *drycc.Client }
+
+func (s buildsService) Get(ctx context.Context, appID string, version int) (api.Build, error) {
+	return builds.GetWithContext(ctx, s.c, appID, version)
+}
+
+func (s buildsService) New(ctx context.Context, appID string, image string, stack string, procfile map[string]string, dryccfile map[string]any) (api.Build, error) {
+	return builds.NewWithContext(ctx, s.c, appID, image, stack, procfile, dryccfile)
+}
+
+type certsService struct{ c *drycc.Client }
+
+func (s certsService) List(ctx context.Context, appID string, results int) ([]api.Cert, int, error) {
+	return certs.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s certsService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Cert, error] {
+	return certs.All(ctx, s.c, appID, pageSize)
+}
+
+func (s certsService) New(ctx context.Context, appID string, cert string, key string, name string) (api.Cert, error) {
+	return certs.NewWithContext(ctx, s.c, appID, cert, key, name)
+}
+
+func (s certsService) Get(ctx context.Context, appID string, name string) (api.Cert, error) {
+	return certs.GetWithContext(ctx, s.c, appID, name)
+}
+
+func (s certsService) Delete(ctx context.Context, appID string, name string) error {
+	return certs.DeleteWithContext(ctx, s.c, appID, name)
+}
+
+func (s certsService) Attach(ctx context.Context, appID string, name string, domain string) error {
+	return certs.AttachWithContext(ctx, s.c, appID, name, domain)
+}
+
+func (s certsService) Detach(ctx context.Context, appID string, name string, domain string) error {
+	return certs.DetachWithContext(ctx, s.c, appID, name, domain)
+}
+
+type configService struct{ c *drycc.Client }
+
+func (s configService) List(ctx context.Context, app string, version int) (api.Config, error) {
+	return config.ListWithContext(ctx, s.c, app, version)
+}
+
+func (s configService) Set(ctx context.Context, app string, cfg api.Config, merge bool) (api.Config, error) {
+	return config.SetWithContext(ctx, s.c, app, cfg, merge)
+}
+
+func (s configService) Detach(ctx context.Context, app string, cfg api.Config) error {
+	return config.DetachWithContext(ctx, s.c, app, cfg)
+}
+
+type domainsService struct{ c *drycc.Client }
+
+func (s domainsService) List(ctx context.Context, appID string, results int) (api.Domains, int, error) {
+	return domains.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s domainsService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Domain, error] {
+	return domains.All(ctx, s.c, appID, pageSize)
+}
+
+func (s domainsService) New(ctx context.Context, appID, domain, ptype string) (api.Domain, error) {
+	return domains.NewWithContext(ctx, s.c, appID, domain, ptype)
+}
+
+func (s domainsService) Delete(ctx context.Context, appID string, domain string) error {
+	return domains.DeleteWithContext(ctx, s.c, appID, domain)
+}
+
+type eventsService struct{ c *drycc.Client }
+
+func (s eventsService) ListPodEvents(ctx context.Context, appID string, podName string, results int) (api.AppEvents, int, error) {
+	return events.ListPodEventsWithContext(ctx, s.c, appID, podName, results)
+}
+
+func (s eventsService) AllPodEvents(ctx context.Context, appID string, podName string, pageSize int) iter.Seq2[api.AppEvent, error] {
+	return events.AllPodEvents(ctx, s.c, appID, podName, pageSize)
+}
+
+func (s eventsService) ListPtypeEvents(ctx context.Context, appID string, ptype string, results int) (api.AppEvents, int, error) {
+	return events.ListPtypeEventsWithContext(ctx, s.c, appID, ptype, results)
+}
+
+func (s eventsService) AllPtypeEvents(ctx context.Context, appID string, ptype string, pageSize int) iter.Seq2[api.AppEvent, error] {
+	return events.AllPtypeEvents(ctx, s.c, appID, ptype, pageSize)
+}
+
+type gatewaysService struct{ c *drycc.Client }
+
+func (s gatewaysService) List(ctx context.Context, appID string, results int) (api.Gateways, int, error) {
+	return gateways.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s gatewaysService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Gateway, error] {
+	return gateways.All(ctx, s.c, appID, pageSize)
+}
+
+func (s gatewaysService) New(ctx context.Context, appID string, name string, port int, protocol string) error {
+	return gateways.NewWithContext(ctx, s.c, appID, name, port, protocol)
+}
+
+func (s gatewaysService) Delete(ctx context.Context, appID string, name string, port int, protocol string) error {
+	return gateways.DeleteWithContext(ctx, s.c, appID, name, port, protocol)
+}
+
+type keysService struct{ c *drycc.Client }
+
+func (s keysService) List(ctx context.Context, results int) (api.Keys, int, error) {
+	return keys.ListWithContext(ctx, s.c, results)
+}
+
+func (s keysService) All(ctx context.Context, pageSize int) iter.Seq2[api.Key, error] {
+	return keys.All(ctx, s.c, pageSize)
+}
+
+func (s keysService) New(ctx context.Context, id string, pubKey string) (api.Key, error) {
+	return keys.NewWithContext(ctx, s.c, id, pubKey)
+}
+
+func (s keysService) Delete(ctx context.Context, keyID string) error {
+	return keys.DeleteWithContext(ctx, s.c, keyID)
+}
+
+type limitsService struct{ c *drycc.Client }
+
+func (s limitsService) Specs(ctx context.Context, keywords string, results int) ([]api.LimitSpec, int, error) {
+	return limits.SpecsWithContext(ctx, s.c, keywords, results)
+}
+
+func (s limitsService) Plans(ctx context.Context, specID string, cpu, memory, results int) ([]api.LimitPlan, int, error) {
+	return limits.PlansWithContext(ctx, s.c, specID, cpu, memory, results)
+}
+
+func (s limitsService) GetPlan(ctx context.Context, planID string) (api.LimitPlan, error) {
+	return limits.GetPlanWithContext(ctx, s.c, planID)
+}
+
+type podsService struct{ c *drycc.Client }
+
+func (s podsService) List(ctx context.Context, appID string, results int) (api.PodsList, int, error) {
+	return ps.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s podsService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Pods, error] {
+	return ps.All(ctx, s.c, appID, pageSize)
+}
+
+func (s podsService) Exec(ctx context.Context, appID, podID string, command api.Command) (*websocket.Conn, error) {
+	return ps.ExecWithContext(ctx, s.c, appID, podID, command)
+}
+
+func (s podsService) NewExecSession(ctx context.Context, appID, podID string, command api.Command) (*ps.ExecSession, error) {
+	return ps.NewExecSession(ctx, s.c, appID, podID, command)
+}
+
+func (s podsService) RunCommand(ctx context.Context, appID, podID string, argv []string) ([]byte, int, error) {
+	return ps.RunCommand(ctx, s.c, appID, podID, argv)
+}
+
+func (s podsService) Logs(ctx context.Context, appID, podID string, request api.PodLogsRequest) (*websocket.Conn, error) {
+	return ps.LogsWithContext(ctx, s.c, appID, podID, request)
+}
+
+func (s podsService) StreamLogs(ctx context.Context, appID string, opts ps.LogsOptions) iter.Seq2[ps.LogLine, error] {
+	return ps.StreamLogs(ctx, s.c, appID, opts)
+}
+
+func (s podsService) DialPort(ctx context.Context, appID, podID string, port int) (io.ReadWriteCloser, error) {
+	return ps.DialPort(ctx, s.c, appID, podID, port)
+}
+
+func (s podsService) PortForward(ctx context.Context, appID, podID string, port int, opts ps.PortForwardOptions) (*ps.PortForwarder, error) {
+	return ps.PortForward(ctx, s.c, appID, podID, port, opts)
+}
+
+func (s podsService) CopyTo(ctx context.Context, appID, podID, src, dst string, opts ps.CopyOptions) error {
+	return ps.CopyTo(ctx, s.c, appID, podID, src, dst, opts)
+}
+
+func (s podsService) CopyFrom(ctx context.Context, appID, podID, src, dst string, opts ps.CopyOptions) error {
+	return ps.CopyFrom(ctx, s.c, appID, podID, src, dst, opts)
+}
+
+func (s podsService) WaitForPods(ctx context.Context, appID string, opts ps.WaitOptions) (api.PodsList, error) {
+	return ps.WaitForPods(ctx, s.c, appID, opts)
+}
+
+func (s podsService) Describe(ctx context.Context, appID string, podID string, results int) (api.PodState, int, error) {
+	return ps.DescribeWithContext(ctx, s.c, appID, podID, results)
+}
+
+func (s podsService) Delete(ctx context.Context, appID string, podIDs string) error {
+	return ps.DeleteWithContext(ctx, s.c, appID, podIDs)
+}
+
+type ptypesService struct{ c *drycc.Client }
+
+func (s ptypesService) List(ctx context.Context, appID string, results int) (api.Ptypes, int, error) {
+	return pts.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s ptypesService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Ptype, error] {
+	return pts.All(ctx, s.c, appID, pageSize)
+}
+
+func (s ptypesService) Describe(ctx context.Context, appID string, ptype string, results int) (api.PtypeStates, int, error) {
+	return pts.DescribeWithContext(ctx, s.c, appID, ptype, results)
+}
+
+func (s ptypesService) Scale(ctx context.Context, appID string, targets map[string]int) error {
+	return pts.ScaleWithContext(ctx, s.c, appID, targets)
+}
+
+func (s ptypesService) Restart(ctx context.Context, appID string, targets map[string]string) error {
+	return pts.RestartWithContext(ctx, s.c, appID, targets)
+}
+
+func (s ptypesService) Clean(ctx context.Context, appID string, targets map[string]string) error {
+	return pts.CleanWithContext(ctx, s.c, appID, targets)
+}
+
+func (s ptypesService) WaitReady(ctx context.Context, appID, ptype string, opts ps.WaitOptions) (api.Ptype, error) {
+	return pts.WaitReady(ctx, s.c, appID, ptype, opts)
+}
+
+type releasesService struct{ c *drycc.Client }
+
+func (s releasesService) List(ctx context.Context, appID, ptypes string, results int) ([]api.Release, int, error) {
+	return releases.ListWithContext(ctx, s.c, appID, ptypes, results)
+}
+
+func (s releasesService) All(ctx context.Context, appID, ptypes string, pageSize int) iter.Seq2[api.Release, error] {
+	return releases.All(ctx, s.c, appID, ptypes, pageSize)
+}
+
+func (s releasesService) Get(ctx context.Context, appID string, version int) (api.Release, error) {
+	return releases.GetWithContext(ctx, s.c, appID, version)
+}
+
+func (s releasesService) Deploy(ctx context.Context, appID string, targets map[string]any) error {
+	return releases.DeployWithContext(ctx, s.c, appID, targets)
+}
+
+func (s releasesService) Rollback(ctx context.Context, appID string, ptypes string, version int) (int, error) {
+	return releases.RollbackWithContext(ctx, s.c, appID, ptypes, version)
+}
+
+type resourcesService struct{ c *drycc.Client }
+
+func (s resourcesService) Services(ctx context.Context, results int) (api.ResourceServices, int, error) {
+	return resources.ServicesWithContext(ctx, s.c, results)
+}
+
+func (s resourcesService) Plans(ctx context.Context, serviceName string, results int) (api.ResourcePlans, int, error) {
+	return resources.PlansWithContext(ctx, s.c, serviceName, results)
+}
+
+func (s resourcesService) List(ctx context.Context, appID string, results int) (api.Resources, int, error) {
+	return resources.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s resourcesService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Resource, error] {
+	return resources.All(ctx, s.c, appID, pageSize)
+}
+
+func (s resourcesService) Create(ctx context.Context, appID string, resource api.Resource) (api.Resource, error) {
+	return resources.CreateWithContext(ctx, s.c, appID, resource)
+}
+
+func (s resourcesService) Get(ctx context.Context, appID string, name string) (api.Resource, error) {
+	return resources.GetWithContext(ctx, s.c, appID, name)
+}
+
+func (s resourcesService) Delete(ctx context.Context, appID string, name string) error {
+	return resources.DeleteWithContext(ctx, s.c, appID, name)
+}
+
+func (s resourcesService) Put(ctx context.Context, appID string, name string, resource api.Resource) (api.Resource, error) {
+	return resources.PutWithContext(ctx, s.c, appID, name, resource)
+}
+
+func (s resourcesService) Binding(ctx context.Context, appID string, name string, resource api.ResourceBinding) (api.Resource, error) {
+	return resources.BindingWithContext(ctx, s.c, appID, name, resource)
+}
+
+type routesService struct{ c *drycc.Client }
+
+func (s routesService) List(ctx context.Context, appID string, results int) (api.Routes, int, error) {
+	return routes.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s routesService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Route, error] {
+	return routes.All(ctx, s.c, appID, pageSize)
+}
+
+func (s routesService) New(ctx context.Context, appID, name, kind string, backendRefs ...api.BackendRefRequest) error {
+	return routes.NewWithContext(ctx, s.c, appID, name, kind, backendRefs...)
+}
+
+func (s routesService) AttachGateway(ctx context.Context, appID string, name string, port int, gateway string) error {
+	return routes.AttachGatewayWithContext(ctx, s.c, appID, name, port, gateway)
+}
+
+func (s routesService) DetachGateway(ctx context.Context, appID string, name string, port int, gateway string) error {
+	return routes.DetachGatewayWithContext(ctx, s.c, appID, name, port, gateway)
+}
+
+func (s routesService) GetRule(ctx context.Context, appID string, name string) (string, error) {
+	return routes.GetRuleWithContext(ctx, s.c, appID, name)
+}
+
+func (s routesService) SetRule(ctx context.Context, appID string, name string, rules string) error {
+	return routes.SetRuleWithContext(ctx, s.c, appID, name, rules)
+}
+
+func (s routesService) Delete(ctx context.Context, appID string, name string) error {
+	return routes.DeleteWithContext(ctx, s.c, appID, name)
+}
+
+type servicesService struct{ c *drycc.Client }
+
+func (s servicesService) List(ctx context.Context, appID string) (api.Services, error) {
+	return services.ListWithContext(ctx, s.c, appID)
+}
+
+func (s servicesService) New(ctx context.Context, appID string, ptype string, port int, protocol string, targetPort int) error {
+	return services.NewWithContext(ctx, s.c, appID, ptype, port, protocol, targetPort)
+}
+
+func (s servicesService) Delete(ctx context.Context, appID string, ptype string, protocol string, port int) error {
+	return services.DeleteWithContext(ctx, s.c, appID, ptype, protocol, port)
+}
+
+type tlsService struct{ c *drycc.Client }
+
+func (s tlsService) Info(ctx context.Context, app string) (api.TLS, error) {
+	return tls.InfoWithContext(ctx, s.c, app)
+}
+
+func (s tlsService) EnableHTTPSEnforced(ctx context.Context, app string) (api.TLS, error) {
+	return tls.EnableHTTPSEnforcedWithContext(ctx, s.c, app)
+}
+
+func (s tlsService) DisableHTTPSEnforced(ctx context.Context, app string) (api.TLS, error) {
+	return tls.DisableHTTPSEnforcedWithContext(ctx, s.c, app)
+}
+
+func (s tlsService) EnableCertsAutoEnabled(ctx context.Context, app string) (api.TLS, error) {
+	return tls.EnableCertsAutoEnabledWithContext(ctx, s.c, app)
+}
+
+func (s tlsService) DisableCertsAutoEnabled(ctx context.Context, app string) (api.TLS, error) {
+	return tls.DisableCertsAutoEnabledWithContext(ctx, s.c, app)
+}
+
+func (s tlsService) AddCertsIssuer(ctx context.Context, app string, email string, server string, keyID string, keySecret string) (api.TLS, error) {
+	return tls.AddCertsIssuerWithContext(ctx, s.c, app, email, server, keyID, keySecret)
+}
+
+type tokensService struct{ c *drycc.Client }
+
+func (s tokensService) List(ctx context.Context, results int) ([]api.Token, int, error) {
+	return tokens.ListWithContext(ctx, s.c, results)
+}
+
+func (s tokensService) All(ctx context.Context, pageSize int) iter.Seq2[api.Token, error] {
+	return tokens.All(ctx, s.c, pageSize)
+}
+
+func (s tokensService) Delete(ctx context.Context, id string) error {
+	return tokens.DeleteWithContext(ctx, s.c, id)
+}
+
+func (s tokensService) Create(ctx context.Context, alias string) (api.TokenCreateResponse, error) {
+	return tokens.CreateWithContext(ctx, s.c, alias)
+}
+
+func (s tokensService) Rotate(ctx context.Context, id string) (api.TokenCreateResponse, error) {
+	return tokens.RotateWithContext(ctx, s.c, id)
+}
+
+func (s tokensService) Prune(ctx context.Context, since time.Time) ([]api.Token, error) {
+	return tokens.PruneWithContext(ctx, s.c, since)
+}
+
+type usersService struct{ c *drycc.Client }
+
+func (s usersService) List(ctx context.Context, results int) (api.Users, int, error) {
+	return users.ListWithContext(ctx, s.c, results)
+}
+
+func (s usersService) All(ctx context.Context, pageSize int) iter.Seq2[api.User, error] {
+	return users.All(ctx, s.c, pageSize)
+}
+
+func (s usersService) Get(ctx context.Context, username string) (api.User, error) {
+	return users.GetWithContext(ctx, s.c, username)
+}
+
+func (s usersService) Activate(ctx context.Context, username string) error {
+	return users.ActivateWithContext(ctx, s.c, username)
+}
+
+func (s usersService) Deactivate(ctx context.Context, username string) error {
+	return users.DeactivateWithContext(ctx, s.c, username)
+}
+
+func (s usersService) SetSuperuser(ctx context.Context, username string, superuser bool) error {
+	return users.SetSuperuserWithContext(ctx, s.c, username, superuser)
+}
+
+type volumesService struct{ c *drycc.Client }
+
+func (s volumesService) List(ctx context.Context, appID string, results int) (api.Volumes, int, error) {
+	return volumes.ListWithContext(ctx, s.c, appID, results)
+}
+
+func (s volumesService) All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Volume, error] {
+	return volumes.All(ctx, s.c, appID, pageSize)
+}
+
+func (s volumesService) Get(ctx context.Context, appID string, name string) (api.Volume, error) {
+	return volumes.GetWithContext(ctx, s.c, appID, name)
+}
+
+func (s volumesService) Create(ctx context.Context, appID string, volume api.Volume) (api.Volume, error) {
+	return volumes.CreateWithContext(ctx, s.c, appID, volume)
+}
+
+func (s volumesService) Expand(ctx context.Context, appID string, volume api.Volume) (api.Volume, error) {
+	return volumes.ExpandWithContext(ctx, s.c, appID, volume)
+}
+
+func (s volumesService) Serve(ctx context.Context, appID, name string) (context.Context, map[string]string, error) {
+	return volumes.Serve(ctx, s.c, appID, name)
+}
+
+func (s volumesService) Delete(ctx context.Context, appID string, name string) error {
+	return volumes.DeleteWithContext(ctx, s.c, appID, name)
+}
+
+func (s volumesService) Mount(ctx context.Context, appID string, name string, volume api.Volume) (api.Volume, error) {
+	return volumes.MountWithContext(ctx, s.c, appID, name, volume)
+}
+
+type workspacesService struct{ c *drycc.Client }
+
+func (s workspacesService) List(ctx context.Context, results int) (api.Workspaces, int, error) {
+	return workspaces.ListWithContext(ctx, s.c, results)
+}
+
+func (s workspacesService) All(ctx context.Context, pageSize int) iter.Seq2[api.Workspace, error] {
+	return workspaces.All(ctx, s.c, pageSize)
+}
+
+func (s workspacesService) Create(ctx context.Context, name, email string) (api.Workspace, error) {
+	return workspaces.CreateWithContext(ctx, s.c, name, email)
+}
+
+func (s workspacesService) Get(ctx context.Context, name string) (api.Workspace, error) {
+	return workspaces.GetWithContext(ctx, s.c, name)
+}
+
+func (s workspacesService) Update(ctx context.Context, name, email string) (api.Workspace, error) {
+	return workspaces.UpdateWithContext(ctx, s.c, name, email)
+}
+
+func (s workspacesService) Delete(ctx context.Context, name string) error {
+	return workspaces.DeleteWithContext(ctx, s.c, name)
+}
+
+type membersService struct{ c *drycc.Client }
+
+func (s membersService) List(ctx context.Context, workspace string, results int) (api.WorkspaceMembers, int, error) {
+	return members.ListWithContext(ctx, s.c, workspace, results)
+}
+
+func (s membersService) All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.WorkspaceMember, error] {
+	return members.All(ctx, s.c, workspace, pageSize)
+}
+
+func (s membersService) Get(ctx context.Context, workspace, user string) (api.WorkspaceMember, error) {
+	return members.GetWithContext(ctx, s.c, workspace, user)
+}
+
+func (s membersService) Update(ctx context.Context, workspace, user, role string, alerts *bool) (api.WorkspaceMember, error) {
+	return members.UpdateWithContext(ctx, s.c, workspace, user, role, alerts)
+}
+
+func (s membersService) Delete(ctx context.Context, workspace, user string) error {
+	return members.DeleteWithContext(ctx, s.c, workspace, user)
+}
+
+type invitationsService struct{ c *drycc.Client }
+
+func (s invitationsService) List(ctx context.Context, workspace string, results int) (api.WorkspaceInvitations, int, error) {
+	return invitations.ListWithContext(ctx, s.c, workspace, results)
+}
+
+func (s invitationsService) All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.WorkspaceInvitation, error] {
+	return invitations.All(ctx, s.c, workspace, pageSize)
+}
+
+func (s invitationsService) Create(ctx context.Context, workspace, email string) (api.WorkspaceInvitation, error) {
+	return invitations.CreateWithContext(ctx, s.c, workspace, email)
+}
+
+func (s invitationsService) Get(ctx context.Context, workspace, uid string) (api.WorkspaceInvitation, error) {
+	return invitations.GetWithContext(ctx, s.c, workspace, uid)
+}
+
+func (s invitationsService) Delete(ctx context.Context, workspace, uid string) error {
+	return invitations.DeleteWithContext(ctx, s.c, workspace, uid)
+}
@@ -0,0 +1,60 @@
+package clientset_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/clientset"
+	"github.com/drycc/controller-sdk-go/drycctest"
+)
+
+func TestClientset(t *testing.T) {
+	t.Parallel()
+
+	server := drycctest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cs clientset.Interface = clientset.New(client)
+	ctx := context.Background()
+
+	if _, err = cs.Apps().New(ctx, "example-go", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cs.Builds().New(ctx, "example-go", "example/go:latest", "container", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = cs.Ptypes().Scale(ctx, "example-go", map[string]int{"web": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for pod, err := range cs.Pods().All(ctx, "example-go", 1) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, pod.Type)
+	}
+	if !reflect.DeepEqual([]string{"web", "web"}, names) {
+		t.Errorf("Expected %v, Got %v", []string{"web", "web"}, names)
+	}
+
+	cfg, err := cs.Config().Set(ctx, "example-go", api.Config{Values: []api.ConfigValue{
+		{Ptype: "web", ConfigVar: api.ConfigVar{Name: "PORT", Value: "8000"}},
+	}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releases, _, err := cs.Releases().List(ctx, "example-go", "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if releases[0].Config != cfg.UUID {
+		t.Errorf("Expected %s, Got %s", cfg.UUID, releases[0].Config)
+	}
+}
@@ -0,0 +1,66 @@
+// Package fake provides fakes of the services of package clientset, for tests of code
+// depending on them:
+//
+//	cs := fake.NewClientset()
+//	cs.AppsService.GetFunc = func(ctx context.Context, appID string) (api.App, error) {
+//	    return api.App{ID: appID}, nil
+//	}
+//
+//	// ... exercise code taking a clientset.Interface with cs ...
+//
+//	if calls := cs.AppsService.CallsTo("Get"); len(calls) != 1 {
+//	    t.Errorf("Expected 1 call, Got %d", len(calls))
+//	}
+//
+// Methods without a function set return zero values, and empty iterators.
+package fake
+
+import (
+	"slices"
+	"sync"
+)
+
+// Call is a call made to a fake. Args holds the arguments, except the context.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder records the calls made to a fake. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// CallsTo returns the calls made so far to method, in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the calls made so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
@@ -0,0 +1,87 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/clientset"
+)
+
+// rename is code under test, depending on the clientset interface.
+func rename(ctx context.Context, cs clientset.Interface, appID, workspace string) error {
+	if _, err := cs.Apps().Get(ctx, appID); err != nil {
+		return err
+	}
+	return cs.Apps().Transfer(ctx, appID, workspace)
+}
+
+func TestFakeFunc(t *testing.T) {
+	t.Parallel()
+
+	cs := NewClientset()
+	cs.AppsService.GetFunc = func(ctx context.Context, appID string) (api.App, error) {
+		return api.App{ID: appID}, nil
+	}
+
+	if err := rename(context.Background(), cs, "example-go", "other"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Call{
+		{Method: "Get", Args: []any{"example-go"}},
+		{Method: "Transfer", Args: []any{"example-go", "other"}},
+	}
+	if actual := cs.AppsService.Calls(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+	if actual := cs.AppsService.CallsTo("Transfer"); !reflect.DeepEqual(expected[1:], actual) {
+		t.Errorf("Expected %v, Got %v", expected[1:], actual)
+	}
+
+	cs.AppsService.Reset()
+	if calls := cs.AppsService.Calls(); len(calls) != 0 {
+		t.Errorf("Expected no calls, Got %v", calls)
+	}
+}
+
+func TestFakeError(t *testing.T) {
+	t.Parallel()
+
+	cs := NewClientset()
+	expected := errors.New("boom")
+	cs.AppsService.GetFunc = func(context.Context, string) (api.App, error) {
+		return api.App{}, expected
+	}
+
+	if err := rename(context.Background(), cs, "example-go", "other"); !errors.Is(err, expected) {
+		t.Errorf("Expected %v, Got %v", expected, err)
+	}
+	if calls := cs.AppsService.CallsTo("Transfer"); len(calls) != 0 {
+		t.Errorf("Expected no transfer, Got %v", calls)
+	}
+}
+
+func TestFakeZeroValues(t *testing.T) {
+	t.Parallel()
+
+	cs := NewClientset()
+	ctx := context.Background()
+
+	list, count, err := cs.Pods().List(ctx, "example-go", 100)
+	if list != nil || count != 0 || err != nil {
+		t.Errorf("Expected zero values, Got %v, %d, %v", list, count, err)
+	}
+	for release := range cs.Releases().All(ctx, "example-go", "", 100) {
+		t.Errorf("Expected an empty iterator, Got %v", release)
+	}
+	if err = cs.Routes().New(ctx, "example-go", "route", "HTTPRoute", api.BackendRefRequest{Name: "web"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []any{"example-go", "route", "HTTPRoute", []api.BackendRefRequest{{Name: "web"}}}
+	if actual := cs.RoutesService.Calls()[0].Args; !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+}
@@ -0,0 +1,269 @@
+// Command fakegen generates fakes for the service interfaces of package clientset.
+//
+// For every interface named like FooService, it writes a FooService struct with a
+// FooFunc field per method, and a Clientset holding all of them:
+//
+//	go run ./internal/fakegen -o fake/services.go services.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const clientsetPath = "github.com/drycc/controller-sdk-go/clientset"
+
+type service struct {
+	name    string
+	methods []method
+}
+
+type method struct {
+	name     string
+	params   []param
+	results  []string
+	variadic bool
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fakegen: ")
+	output := flag.String("o", "", "output file")
+	flag.Parse()
+	if *output == "" || flag.NArg() != 1 {
+		log.Fatal("usage: fakegen -o output source")
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, flag.Arg(0), nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	services, imports := parse(fset, file)
+	source, err := format.Source(generate(flag.Arg(0), services, imports))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*output, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse returns the service interfaces of file and the imports their methods use.
+func parse(fset *token.FileSet, file *ast.File) ([]service, []string) {
+	paths := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		paths[name] = path
+	}
+
+	used := map[string]bool{clientsetPath: true}
+	expr := func(e ast.Expr) string {
+		ast.Inspect(e, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					used[paths[id.Name]] = true
+				}
+			}
+			return true
+		})
+		var b bytes.Buffer
+		printer.Fprint(&b, fset, e)
+		return b.String()
+	}
+
+	var services []service
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !strings.HasSuffix(ts.Name.Name, "Service") {
+				continue
+			}
+			s := service{name: ts.Name.Name}
+			for _, field := range iface.Methods.List {
+				fn := field.Type.(*ast.FuncType)
+				m := method{name: field.Names[0].Name}
+				for _, p := range fn.Params.List {
+					typ := p.Type
+					if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+						m.variadic = true
+						typ = ellipsis.Elt
+					}
+					for _, name := range p.Names {
+						m.params = append(m.params, param{name: name.Name, typ: expr(typ)})
+					}
+				}
+				if fn.Results != nil {
+					for _, r := range fn.Results.List {
+						m.results = append(m.results, expr(r.Type))
+					}
+				}
+				s.methods = append(s.methods, m)
+			}
+			services = append(services, s)
+		}
+	}
+
+	var imports []string
+	for path := range used {
+		imports = append(imports, path)
+	}
+	slices.Sort(imports)
+	return services, imports
+}
+
+func generate(source string, services []service, imports []string) []byte {
+	var b bytes.Buffer
+	p := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+	}
+
+	p("// Code generated by fakegen from %s. DO NOT EDIT.\n\n", source)
+	p("package fake\n\nimport (\n")
+	// Standard packages come first, in their own group.
+	for _, std := range []bool{true, false} {
+		for _, path := range imports {
+			if !strings.Contains(strings.Split(path, "/")[0], ".") == std {
+				p("\t%q\n", path)
+			}
+		}
+		if std {
+			p("\n")
+		}
+	}
+	p(")\n\n")
+
+	p("// Clientset is a fake [clientset.Interface] whose services are fakes.\n")
+	p("type Clientset struct {\n")
+	for _, s := range services {
+		p("\t%s *%s\n", s.name, s.name)
+	}
+	p("}\n\nvar _ clientset.Interface = (*Clientset)(nil)\n\n")
+	p("// NewClientset returns a Clientset with a fake for every service.\n")
+	p("func NewClientset() *Clientset {\n\treturn &Clientset{\n")
+	for _, s := range services {
+		p("\t\t%s: &%s{},\n", s.name, s.name)
+	}
+	p("\t}\n}\n")
+	for _, s := range services {
+		accessor := strings.TrimSuffix(s.name, "Service")
+		p("\n// %s returns the fake %s.\n", accessor, s.name)
+		p("func (c *Clientset) %s() clientset.%s {\n\treturn c.%s\n}\n", accessor, s.name, s.name)
+	}
+
+	for _, s := range services {
+		p("\n// %s is a fake [clientset.%s]. Its methods call the function in the field of the\n", s.name, s.name)
+		p("// same name with a Func suffix if it is set, and return zero values otherwise.\n")
+		p("type %s struct {\n\tRecorder\n\n", s.name)
+		for _, m := range s.methods {
+			p("\t%sFunc func(%s) %s\n", m.name, m.signature(), m.resultList())
+		}
+		p("}\n\nvar _ clientset.%s = (*%s)(nil)\n", s.name, s.name)
+
+		for _, m := range s.methods {
+			p("\n// %s records the call and calls %sFunc.\n", m.name, m.name)
+			p("func (f *%s) %s(%s) %s {\n", s.name, m.name, m.signature(), m.resultList())
+			p("\tf.record(%q%s)\n", m.name, m.recorded())
+			p("\tif f.%sFunc != nil {\n\t\treturn f.%sFunc(%s)\n\t}\n", m.name, m.name, m.arguments())
+			p("%s", m.zeroReturn())
+			p("}\n")
+		}
+	}
+	return b.Bytes()
+}
+
+func (m method) signature() string {
+	var params []string
+	for i, p := range m.params {
+		if m.variadic && i == len(m.params)-1 {
+			params = append(params, p.name+" ..."+p.typ)
+		} else {
+			params = append(params, p.name+" "+p.typ)
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+func (m method) resultList() string {
+	if len(m.results) == 1 {
+		return m.results[0]
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+// recorded lists the arguments recorded for a call: all of them but the context.
+func (m method) recorded() string {
+	var args []string
+	for _, p := range m.params {
+		if p.typ != "context.Context" {
+			args = append(args, p.name)
+		}
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
+
+func (m method) arguments() string {
+	var args []string
+	for i, p := range m.params {
+		if m.variadic && i == len(m.params)-1 {
+			args = append(args, p.name+"...")
+		} else {
+			args = append(args, p.name)
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// zeroReturn returns the zero values of the results. Iterators are empty rather than nil,
+// so that ranging over them works.
+func (m method) zeroReturn() string {
+	var vars, values []string
+	for i, r := range m.results {
+		switch {
+		case r == "error" || strings.HasPrefix(r, "*") || strings.HasPrefix(r, "[]") || strings.HasPrefix(r, "map["):
+			values = append(values, "nil")
+		case r == "string":
+			values = append(values, `""`)
+		case r == "int" || r == "bool":
+			values = append(values, map[string]string{"int": "0", "bool": "false"}[r])
+		case strings.HasPrefix(r, "iter.Seq2["):
+			values = append(values, "func(func("+strings.TrimSuffix(strings.TrimPrefix(r, "iter.Seq2["), "]")+") bool) {}")
+		case r == "context.Context" && len(m.params) > 0 && m.params[0].typ == r:
+			values = append(values, m.params[0].name)
+		default:
+			name := fmt.Sprintf("r%d", i)
+			vars = append(vars, fmt.Sprintf("\tvar %s %s\n", name, r))
+			values = append(values, name)
+		}
+	}
+	return strings.Join(vars, "") + "\treturn " + strings.Join(values, ", ") + "\n"
+}
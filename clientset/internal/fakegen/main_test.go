@@ -0,0 +1,30 @@
+package main
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+)
+
+func TestFakesUpToDate(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../../services.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	services, imports := parse(fset, file)
+	expected, err := format.Source(generate("services.go", services, imports))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := os.ReadFile("../../fake/services.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected) != string(actual) {
+		t.Error("fake/services.go is out of date, run go generate ./clientset/...")
+	}
+}
@@ -0,0 +1,250 @@
+package clientset
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/auth"
+	"github.com/drycc/controller-sdk-go/ps"
+	"golang.org/x/net/websocket"
+)
+
+//go:generate go run ./internal/fakegen -o fake/services.go services.go
+
+// AllowlistService manages the addresses allowed to reach apps. See package allowlist.
+type AllowlistService interface {
+	List(ctx context.Context, appID string) (api.Allowlist, error)
+	Add(ctx context.Context, appID string, addresses []string) (api.Allowlist, error)
+	Delete(ctx context.Context, appID string, addresses []string) error
+}
+
+// AppsService manages apps. See package apps.
+type AppsService interface {
+	List(ctx context.Context, workspace string, results int) (api.Apps, int, error)
+	All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.App, error]
+	New(ctx context.Context, appID string, workspace string) (api.App, error)
+	Get(ctx context.Context, appID string) (api.App, error)
+	Run(ctx context.Context, appID string, command string, volumes map[string]any, timeout, expires uint32) error
+	Delete(ctx context.Context, appID string) error
+	Transfer(ctx context.Context, appID string, workspace string) error
+}
+
+// AppSettingsService manages the settings of apps. See package appsettings.
+type AppSettingsService interface {
+	List(ctx context.Context, app string) (api.AppSettings, error)
+	Set(ctx context.Context, app string, appSettings api.AppSettings) (api.AppSettings, error)
+}
+
+// AuthService authenticates users. See package auth.
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (string, error)
+	LoginInteractive(ctx context.Context, opts ...auth.InteractiveOption) (api.AuthTokenResponse, error)
+	Token(ctx context.Context, key, alias string) (api.AuthTokenResponse, error)
+	Whoami(ctx context.Context) (api.User, error)
+}
+
+// BuildsService manages the builds of apps. See package builds.
+type BuildsService interface {
+	Get(ctx context.Context, appID string, version int) (api.Build, error)
+	New(ctx context.Context, appID string, image string, stack string, procfile map[string]string, dryccfile map[string]any) (api.Build, error)
+}
+
+// CertsService manages the certificates of apps. See package certs.
+type CertsService interface {
+	List(ctx context.Context, appID string, results int) ([]api.Cert, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Cert, error]
+	New(ctx context.Context, appID string, cert string, key string, name string) (api.Cert, error)
+	Get(ctx context.Context, appID string, name string) (api.Cert, error)
+	Delete(ctx context.Context, appID string, name string) error
+	Attach(ctx context.Context, appID string, name string, domain string) error
+	Detach(ctx context.Context, appID string, name string, domain string) error
+}
+
+// ConfigService manages the config of apps. See package config.
+type ConfigService interface {
+	List(ctx context.Context, app string, version int) (api.Config, error)
+	Set(ctx context.Context, app string, cfg api.Config, merge bool) (api.Config, error)
+	Detach(ctx context.Context, app string, cfg api.Config) error
+}
+
+// DomainsService manages the domains of apps. See package domains.
+type DomainsService interface {
+	List(ctx context.Context, appID string, results int) (api.Domains, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Domain, error]
+	New(ctx context.Context, appID, domain, ptype string) (api.Domain, error)
+	Delete(ctx context.Context, appID string, domain string) error
+}
+
+// EventsService lists the events of pods and process types. See package events.
+type EventsService interface {
+	ListPodEvents(ctx context.Context, appID string, podName string, results int) (api.AppEvents, int, error)
+	AllPodEvents(ctx context.Context, appID string, podName string, pageSize int) iter.Seq2[api.AppEvent, error]
+	ListPtypeEvents(ctx context.Context, appID string, ptype string, results int) (api.AppEvents, int, error)
+	AllPtypeEvents(ctx context.Context, appID string, ptype string, pageSize int) iter.Seq2[api.AppEvent, error]
+}
+
+// GatewaysService manages the gateways of apps. See package gateways.
+type GatewaysService interface {
+	List(ctx context.Context, appID string, results int) (api.Gateways, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Gateway, error]
+	New(ctx context.Context, appID string, name string, port int, protocol string) error
+	Delete(ctx context.Context, appID string, name string, port int, protocol string) error
+}
+
+// KeysService manages the SSH keys of the user. See package keys.
+type KeysService interface {
+	List(ctx context.Context, results int) (api.Keys, int, error)
+	All(ctx context.Context, pageSize int) iter.Seq2[api.Key, error]
+	New(ctx context.Context, id string, pubKey string) (api.Key, error)
+	Delete(ctx context.Context, keyID string) error
+}
+
+// LimitsService lists the resource limits available to apps. See package limits.
+type LimitsService interface {
+	Specs(ctx context.Context, keywords string, results int) ([]api.LimitSpec, int, error)
+	Plans(ctx context.Context, specID string, cpu, memory, results int) ([]api.LimitPlan, int, error)
+	GetPlan(ctx context.Context, planID string) (api.LimitPlan, error)
+}
+
+// PodsService manages the pods of apps. See package ps.
+type PodsService interface {
+	List(ctx context.Context, appID string, results int) (api.PodsList, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Pods, error]
+	Exec(ctx context.Context, appID, podID string, command api.Command) (*websocket.Conn, error)
+	NewExecSession(ctx context.Context, appID, podID string, command api.Command) (*ps.ExecSession, error)
+	RunCommand(ctx context.Context, appID, podID string, argv []string) ([]byte, int, error)
+	Logs(ctx context.Context, appID, podID string, request api.PodLogsRequest) (*websocket.Conn, error)
+	StreamLogs(ctx context.Context, appID string, opts ps.LogsOptions) iter.Seq2[ps.LogLine, error]
+	DialPort(ctx context.Context, appID, podID string, port int) (io.ReadWriteCloser, error)
+	PortForward(ctx context.Context, appID, podID string, port int, opts ps.PortForwardOptions) (*ps.PortForwarder, error)
+	CopyTo(ctx context.Context, appID, podID, src, dst string, opts ps.CopyOptions) error
+	CopyFrom(ctx context.Context, appID, podID, src, dst string, opts ps.CopyOptions) error
+	WaitForPods(ctx context.Context, appID string, opts ps.WaitOptions) (api.PodsList, error)
+	Describe(ctx context.Context, appID string, podID string, results int) (api.PodState, int, error)
+	Delete(ctx context.Context, appID string, podIDs string) error
+}
+
+// PtypesService manages the process types of apps. See package pts.
+type PtypesService interface {
+	List(ctx context.Context, appID string, results int) (api.Ptypes, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Ptype, error]
+	Describe(ctx context.Context, appID string, ptype string, results int) (api.PtypeStates, int, error)
+	Scale(ctx context.Context, appID string, targets map[string]int) error
+	Restart(ctx context.Context, appID string, targets map[string]string) error
+	Clean(ctx context.Context, appID string, targets map[string]string) error
+	WaitReady(ctx context.Context, appID, ptype string, opts ps.WaitOptions) (api.Ptype, error)
+}
+
+// ReleasesService manages the releases of apps. See package releases.
+type ReleasesService interface {
+	List(ctx context.Context, appID, ptypes string, results int) ([]api.Release, int, error)
+	All(ctx context.Context, appID, ptypes string, pageSize int) iter.Seq2[api.Release, error]
+	Get(ctx context.Context, appID string, version int) (api.Release, error)
+	Deploy(ctx context.Context, appID string, targets map[string]any) error
+	Rollback(ctx context.Context, appID string, ptypes string, version int) (int, error)
+}
+
+// ResourcesService manages the resources of apps. See package resources.
+type ResourcesService interface {
+	Services(ctx context.Context, results int) (api.ResourceServices, int, error)
+	Plans(ctx context.Context, serviceName string, results int) (api.ResourcePlans, int, error)
+	List(ctx context.Context, appID string, results int) (api.Resources, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Resource, error]
+	Create(ctx context.Context, appID string, resource api.Resource) (api.Resource, error)
+	Get(ctx context.Context, appID string, name string) (api.Resource, error)
+	Delete(ctx context.Context, appID string, name string) error
+	Put(ctx context.Context, appID string, name string, resource api.Resource) (api.Resource, error)
+	Binding(ctx context.Context, appID string, name string, resource api.ResourceBinding) (api.Resource, error)
+}
+
+// RoutesService manages the routes of apps. See package routes.
+type RoutesService interface {
+	List(ctx context.Context, appID string, results int) (api.Routes, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Route, error]
+	New(ctx context.Context, appID, name, kind string, backendRefs ...api.BackendRefRequest) error
+	AttachGateway(ctx context.Context, appID string, name string, port int, gateway string) error
+	DetachGateway(ctx context.Context, appID string, name string, port int, gateway string) error
+	GetRule(ctx context.Context, appID string, name string) (string, error)
+	SetRule(ctx context.Context, appID string, name string, rules string) error
+	Delete(ctx context.Context, appID string, name string) error
+}
+
+// ServicesService manages the Kubernetes services of apps. See package services.
+type ServicesService interface {
+	List(ctx context.Context, appID string) (api.Services, error)
+	New(ctx context.Context, appID string, ptype string, port int, protocol string, targetPort int) error
+	Delete(ctx context.Context, appID string, ptype string, protocol string, port int) error
+}
+
+// TLSService manages the TLS settings of apps. See package tls.
+type TLSService interface {
+	Info(ctx context.Context, app string) (api.TLS, error)
+	EnableHTTPSEnforced(ctx context.Context, app string) (api.TLS, error)
+	DisableHTTPSEnforced(ctx context.Context, app string) (api.TLS, error)
+	EnableCertsAutoEnabled(ctx context.Context, app string) (api.TLS, error)
+	DisableCertsAutoEnabled(ctx context.Context, app string) (api.TLS, error)
+	AddCertsIssuer(ctx context.Context, app string, email string, server string, keyID string, keySecret string) (api.TLS, error)
+}
+
+// TokensService manages the tokens of the user. See package tokens.
+type TokensService interface {
+	List(ctx context.Context, results int) ([]api.Token, int, error)
+	All(ctx context.Context, pageSize int) iter.Seq2[api.Token, error]
+	Delete(ctx context.Context, id string) error
+	Create(ctx context.Context, alias string) (api.TokenCreateResponse, error)
+	Rotate(ctx context.Context, id string) (api.TokenCreateResponse, error)
+	Prune(ctx context.Context, since time.Time) ([]api.Token, error)
+}
+
+// UsersService administers the users of the controller. See package users.
+type UsersService interface {
+	List(ctx context.Context, results int) (api.Users, int, error)
+	All(ctx context.Context, pageSize int) iter.Seq2[api.User, error]
+	Get(ctx context.Context, username string) (api.User, error)
+	Activate(ctx context.Context, username string) error
+	Deactivate(ctx context.Context, username string) error
+	SetSuperuser(ctx context.Context, username string, superuser bool) error
+}
+
+// VolumesService manages the volumes of apps. See package volumes.
+type VolumesService interface {
+	List(ctx context.Context, appID string, results int) (api.Volumes, int, error)
+	All(ctx context.Context, appID string, pageSize int) iter.Seq2[api.Volume, error]
+	Get(ctx context.Context, appID string, name string) (api.Volume, error)
+	Create(ctx context.Context, appID string, volume api.Volume) (api.Volume, error)
+	Expand(ctx context.Context, appID string, volume api.Volume) (api.Volume, error)
+	Serve(ctx context.Context, appID, name string) (context.Context, map[string]string, error)
+	Delete(ctx context.Context, appID string, name string) error
+	Mount(ctx context.Context, appID string, name string, volume api.Volume) (api.Volume, error)
+}
+
+// WorkspacesService manages workspaces. See package workspaces.
+type WorkspacesService interface {
+	List(ctx context.Context, results int) (api.Workspaces, int, error)
+	All(ctx context.Context, pageSize int) iter.Seq2[api.Workspace, error]
+	Create(ctx context.Context, name, email string) (api.Workspace, error)
+	Get(ctx context.Context, name string) (api.Workspace, error)
+	Update(ctx context.Context, name, email string) (api.Workspace, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// MembersService manages the members of workspaces. See package members.
+type MembersService interface {
+	List(ctx context.Context, workspace string, results int) (api.WorkspaceMembers, int, error)
+	All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.WorkspaceMember, error]
+	Get(ctx context.Context, workspace, user string) (api.WorkspaceMember, error)
+	Update(ctx context.Context, workspace, user, role string, alerts *bool) (api.WorkspaceMember, error)
+	Delete(ctx context.Context, workspace, user string) error
+}
+
+// InvitationsService manages the invitations to workspaces. See package invitations.
+type InvitationsService interface {
+	List(ctx context.Context, workspace string, results int) (api.WorkspaceInvitations, int, error)
+	All(ctx context.Context, workspace string, pageSize int) iter.Seq2[api.WorkspaceInvitation, error]
+	Create(ctx context.Context, workspace, email string) (api.WorkspaceInvitation, error)
+	Get(ctx context.Context, workspace, uid string) (api.WorkspaceInvitation, error)
+	Delete(ctx context.Context, workspace, uid string) error
+}
@@ -2,7 +2,7 @@
 package config
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -11,23 +11,16 @@ import (
 
 // List lists an app's config.
 func List(c *drycc.Client, app string, version int) (api.Config, error) {
+	return ListWithContext(context.Background(), c, app, version)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, app string, version int) (api.Config, error) {
 	u := fmt.Sprintf("/v2/apps/%s/config/", app)
 	if version > 0 {
 		u = fmt.Sprintf("%s?version=v%d", u, version)
 	}
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil {
-		return api.Config{}, reqErr
-	}
-	defer res.Body.Close()
-
-	config := api.Config{}
-	if err := json.NewDecoder(res.Body).Decode(&config); err != nil {
-		return api.Config{}, err
-	}
-
-	return config, reqErr
+	return drycc.Get[api.Config](ctx, c, u)
 }
 
 // Set sets an app's config variables and creates a new release.
@@ -42,40 +35,20 @@ func List(c *drycc.Client, app string, version int) (api.Config, error) {
 // Trying to unset a key that does not exist returns a drycc.ErrUnprocessable.
 // Trying to set a tag that is not a label in the kubernetes cluster will return a drycc.ErrTagNotFound.
 func Set(c *drycc.Client, app string, config api.Config, merge bool) (api.Config, error) {
-	body, err := json.Marshal(config)
-	if err != nil {
-		return api.Config{}, err
-	}
-
-	u := fmt.Sprintf("/v2/apps/%s/config/?merge=%v", app, merge)
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil {
-		return api.Config{}, reqErr
-	}
-	defer res.Body.Close()
-
-	newConfig := api.Config{}
-	if err = json.NewDecoder(res.Body).Decode(&newConfig); err != nil {
-		return api.Config{}, err
-	}
+	return SetWithContext(context.Background(), c, app, config, merge)
+}
 
-	return newConfig, reqErr
+// SetWithContext is like [Set] but carries ctx.
+func SetWithContext(ctx context.Context, c *drycc.Client, app string, config api.Config, merge bool) (api.Config, error) {
+	return drycc.Post[api.Config](ctx, c, fmt.Sprintf("/v2/apps/%s/config/?merge=%v", app, merge), config)
 }
 
 // Detach config groups from app ptype.
 func Detach(c *drycc.Client, app string, config api.Config) error {
-	body, err := json.Marshal(config)
-	if err != nil {
-		return err
-	}
-
-	u := fmt.Sprintf("/v2/apps/%s/config/", app)
-
-	res, reqErr := c.Request("DELETE", u, body)
-	if reqErr != nil {
-		return reqErr
-	}
-	defer res.Body.Close()
+	return DetachWithContext(context.Background(), c, app, config)
+}
 
-	return reqErr
+// DetachWithContext is like [Detach] but carries ctx.
+func DetachWithContext(ctx context.Context, c *drycc.Client, app string, config api.Config) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/config/", app), config)
 }
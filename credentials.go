@@ -0,0 +1,280 @@
+package drycc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNoCredential is returned by a CredentialStore holding no token for a controller
+	// and user.
+	ErrNoCredential = errors.New("no stored credential")
+	// ErrPassphrase is returned by a FileStore that can't decrypt its file, because the
+	// passphrase is wrong or missing.
+	ErrPassphrase = errors.New("wrong passphrase for the credential store")
+)
+
+// pbkdf2Iterations is the cost of deriving the key of an encrypted FileStore.
+const pbkdf2Iterations = 600_000
+
+// CredentialStore stores the tokens of users, keyed by controller URL and username. The
+// controller URL is normalized as by New, so that "drycc.example.com" and
+// "http://drycc.example.com/" are the same controller.
+type CredentialStore interface {
+	// Get returns the token of a user, or ErrNoCredential.
+	Get(controller, username string) (string, error)
+	// Set stores the token of a user, replacing any previous one.
+	Set(controller, username, token string) error
+	// Delete forgets the token of a user. Deleting a missing token isn't an error.
+	Delete(controller, username string) error
+}
+
+// credentialKey returns the key of the token of a user in a store.
+func credentialKey(controller, username string) string {
+	if !strings.HasPrefix(controller, "http://") && !strings.HasPrefix(controller, "https://") {
+		controller = "http://" + controller
+	}
+	return username + "@" + strings.TrimRight(controller, "/")
+}
+
+// MemoryStore is a CredentialStore keeping tokens in memory, for tests and short-lived
+// programs. It is safe for concurrent use.
+type MemoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+var _ CredentialStore = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: map[string]string{}}
+}
+
+// Get returns the token of a user, or ErrNoCredential.
+func (s *MemoryStore) Get(controller, username string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[credentialKey(controller, username)]
+	if !ok {
+		return "", ErrNoCredential
+	}
+	return token, nil
+}
+
+// Set stores the token of a user.
+func (s *MemoryStore) Set(controller, username, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[credentialKey(controller, username)] = token
+	return nil
+}
+
+// Delete forgets the token of a user.
+func (s *MemoryStore) Delete(controller, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, credentialKey(controller, username))
+	return nil
+}
+
+// CredentialsPath returns the file of the default FileStore, ~/.drycc/credentials.
+func CredentialsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials"), nil
+}
+
+// FileStore is a CredentialStore keeping tokens in a JSON file only readable by the
+// current user, optionally encrypted with a passphrase. The file is read on every call,
+// so that several programs can share it, and replaced atomically on changes. It is safe
+// for concurrent use within a program.
+type FileStore struct {
+	path       string
+	passphrase string
+
+	mu sync.Mutex
+	// salt and key cache the key derived from the passphrase, which is slow on purpose.
+	salt []byte
+	key  []byte
+}
+
+var _ CredentialStore = (*FileStore)(nil)
+
+// credentialsFile is the contents of the file of a FileStore.
+type credentialsFile struct {
+	Credentials map[string]string `json:"credentials,omitempty"`
+	// Encrypted holds the credentials sealed with the passphrase instead.
+	Encrypted *sealedCredentials `json:"encrypted,omitempty"`
+}
+
+type sealedCredentials struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// NewFileStore returns a FileStore keeping tokens in plaintext in the file at path, which
+// is created on the first Set.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// NewEncryptedFileStore returns a FileStore encrypting the file at path with a key derived
+// from passphrase. A plaintext file is read as is and encrypted on the next change.
+func NewEncryptedFileStore(path, passphrase string) *FileStore {
+	return &FileStore{path: path, passphrase: passphrase}
+}
+
+// Get returns the token of a user, or ErrNoCredential.
+func (s *FileStore) Get(controller, username string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[credentialKey(controller, username)]
+	if !ok {
+		return "", ErrNoCredential
+	}
+	return token, nil
+}
+
+// Set stores the token of a user.
+func (s *FileStore) Set(controller, username, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[credentialKey(controller, username)] = token
+	return s.write(tokens)
+}
+
+// Delete forgets the token of a user.
+func (s *FileStore) Delete(controller, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	key := credentialKey(controller, username)
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return s.write(tokens)
+}
+
+func (s *FileStore) read() (map[string]string, error) {
+	contents, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	file := credentialsFile{}
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, err
+	}
+	if file.Encrypted == nil {
+		if file.Credentials == nil {
+			file.Credentials = map[string]string{}
+		}
+		return file.Credentials, nil
+	}
+
+	if s.passphrase == "" {
+		return nil, ErrPassphrase
+	}
+	aead, err := s.aead(file.Encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, file.Encrypted.Nonce, file.Encrypted.Data, nil)
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (s *FileStore) write(tokens map[string]string) error {
+	file := credentialsFile{Credentials: tokens}
+	if s.passphrase != "" {
+		salt := s.salt
+		if salt == nil {
+			salt = make([]byte, 16)
+			rand.Read(salt)
+		}
+		aead, err := s.aead(salt)
+		if err != nil {
+			return err
+		}
+		plaintext, err := json.Marshal(tokens)
+		if err != nil {
+			return err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		rand.Read(nonce)
+		file = credentialsFile{Encrypted: &sealedCredentials{
+			Salt: salt, Nonce: nonce, Data: aead.Seal(nil, nonce, plaintext, nil),
+		}}
+	}
+	contents, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	// CreateTemp creates the file with 0600 permissions.
+	tmp, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// aead returns the cipher of the passphrase with the given salt.
+func (s *FileStore) aead(salt []byte) (cipher.AEAD, error) {
+	if s.key == nil || string(salt) != string(s.salt) {
+		key, err := pbkdf2.Key(sha256.New, s.passphrase, salt, pbkdf2Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		s.salt, s.key = salt, key
+	}
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,97 @@
+package drycc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testCredentialStore(t *testing.T, store CredentialStore) {
+	t.Helper()
+
+	if _, err := store.Get("drycc.example.com", "test"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Expected %v, Got %v", ErrNoCredential, err)
+	}
+	if err := store.Set("drycc.example.com", "test", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("https://other.example.com", "test", "def"); err != nil {
+		t.Fatal(err)
+	}
+	// Controller URLs are normalized as by New.
+	if token, err := store.Get("http://drycc.example.com/", "test"); err != nil || token != "abc" {
+		t.Errorf("Expected abc, Got %s (%v)", token, err)
+	}
+	if _, err := store.Get("drycc.example.com", "other"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Expected %v, Got %v", ErrNoCredential, err)
+	}
+
+	if err := store.Delete("drycc.example.com", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("drycc.example.com", "test"); err != nil {
+		t.Errorf("Expected deleting a missing token to succeed, Got %v", err)
+	}
+	if _, err := store.Get("drycc.example.com", "test"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Expected %v, Got %v", ErrNoCredential, err)
+	}
+	if token, err := store.Get("https://other.example.com", "test"); err != nil || token != "def" {
+		t.Errorf("Expected def, Got %s (%v)", token, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	testCredentialStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".drycc", "credentials")
+	testCredentialStore(t, NewFileStore(path))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected 0600, Got %o", perm)
+	}
+	// Another store sees the tokens written to the file.
+	if token, err := NewFileStore(path).Get("https://other.example.com", "test"); err != nil || token != "def" {
+		t.Errorf("Expected def, Got %s (%v)", token, err)
+	}
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := NewFileStore(path).Set("drycc.example.com", "plain", "xyz"); err != nil {
+		t.Fatal(err)
+	}
+	testCredentialStore(t, NewEncryptedFileStore(path, "secret"))
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(contents), "def") || strings.Contains(string(contents), "example.com") {
+		t.Errorf("Expected the file to be encrypted, Got %s", contents)
+	}
+
+	// The plaintext token was encrypted along with the others.
+	if token, err := NewEncryptedFileStore(path, "secret").Get("drycc.example.com", "plain"); err != nil || token != "xyz" {
+		t.Errorf("Expected xyz, Got %s (%v)", token, err)
+	}
+	if _, err := NewEncryptedFileStore(path, "wrong").Get("drycc.example.com", "plain"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("Expected %v, Got %v", ErrPassphrase, err)
+	}
+	if _, err := NewFileStore(path).Get("drycc.example.com", "plain"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("Expected %v, Got %v", ErrPassphrase, err)
+	}
+}
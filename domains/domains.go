@@ -2,8 +2,9 @@
 package domains
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,52 +12,37 @@ import (
 
 // List domains registered with an app.
 func List(c *drycc.Client, appID string, results int) (api.Domains, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/domains/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Domain{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var domains []api.Domain
-	if err := json.Unmarshal([]byte(body), &domains); err != nil {
-		return []api.Domain{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Domains, int, error) {
+	return drycc.List[api.Domain](ctx, c, fmt.Sprintf("/v2/apps/%s/domains/", appID), results)
+}
 
-	return domains, count, reqErr
+// All returns an iterator over every domain of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Domain, error] {
+	return drycc.All[api.Domain](ctx, c, fmt.Sprintf("/v2/apps/%s/domains/", appID), pageSize)
 }
 
 // New adds a domain to an app.
 func New(c *drycc.Client, appID, domain, Ptype string) (api.Domain, error) {
-	u := fmt.Sprintf("/v2/apps/%s/domains/", appID)
+	return NewWithContext(context.Background(), c, appID, domain, Ptype)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID, domain, Ptype string) (api.Domain, error) {
 	req := api.DomainCreateRequest{Domain: domain, Ptype: Ptype}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Domain{}, err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Domain{}, reqErr
-	}
-	defer res.Body.Close()
-
-	d := api.Domain{}
-	if err = json.NewDecoder(res.Body).Decode(&d); err != nil {
-		return api.Domain{}, err
-	}
-
-	return d, reqErr
+	return drycc.Post[api.Domain](ctx, c, fmt.Sprintf("/v2/apps/%s/domains/", appID), req)
 }
 
 // Delete removes a domain from an app.
 func Delete(c *drycc.Client, appID string, domain string) error {
-	u := fmt.Sprintf("/v2/apps/%s/domains/%s", appID, domain)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, appID, domain)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, domain string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/domains/%s", appID, domain), nil)
 }
@@ -43,7 +43,44 @@
 //	    log.Fatal(err)
 //	}
 //	// Set the client to use the retrieved token
-//	client.Token = token
+//	client.SetToken(token)
+//
+// # Profiles
+//
+// Tools can reuse the settings the Drycc CLI writes under ~/.drycc instead of asking for
+// the controller URL and token. DRYCC_PROFILE selects the profile, while
+// DRYCC_CONTROLLER_URL and DRYCC_TOKEN override its settings.
+//
+//	client, _, err := drycc.NewFromProfile("")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// A [CredentialStore] keeps tokens apart from the profile files, which only keep a copy
+// for the Drycc CLI when saved with [Profile.KeepToken]. [FileStore] writes them to a file
+// only readable by the current user, optionally encrypted with a passphrase:
+//
+//	path, err := drycc.CredentialsPath()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client, profile, err := drycc.NewFromProfileWithStore("", drycc.NewEncryptedFileStore(path, passphrase))
+//
+// Long-running programs can recover from a revoked token with a [TokenSource], which the
+// client asks for a new token when a request fails with ErrUnauthorized:
+//
+//	client.TokenSource = drycc.StoreTokenSource(store, profile.Controller, profile.Username)
+//
+// # Warnings
+//
+// An API version mismatch or a deprecated endpoint doesn't make requests fail. The client
+// records them instead, see [Client.Warnings] and [Client.OnWarning]. A strict client
+// turns them into errors:
+//
+//	client.Strict = true
+//	client.OnWarning = func(w drycc.Warning) {
+//	    log.Printf("warning: %s", w)
+//	}
 //
 // # Learning More
 //
@@ -51,14 +88,19 @@
 package drycc
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 )
 
-// Client oversees the interaction between the drycc and controller
+// Client oversees the interaction between the drycc and controller.
+//
+// A Client is safe for concurrent use by multiple goroutines once configured; its fields
+// and middlewares must not be changed while requests are in flight.
 type Client struct {
 	// HTTPClient is the transport that is used to communicate with the API.
 	HTTPClient *http.Client
@@ -67,6 +109,10 @@ type Client struct {
 	// This should be true unless you know the controller is using untrusted SSL keys.
 	VerifySSL bool
 
+	// TLSConfig is the TLS configuration of connections to the controller, used by
+	// websockets as well as by the transport New creates.
+	TLSConfig *tls.Config
+
 	// ControllerURL is the URL used to communicate with the controller.
 	ControllerURL *url.URL
 
@@ -74,18 +120,57 @@ type Client struct {
 	UserAgent string
 
 	// API Version used by the controller, set after a http request.
+	//
+	// Deprecated: reading the field races with requests made concurrently, use
+	// [Client.Versions] instead.
 	ControllerAPIVersion string
 
 	// Version of the drycc controller in use, set after a http request.
+	//
+	// Deprecated: reading the field races with requests made concurrently, use
+	// [Client.Versions] instead.
 	ControllerVersion string
 
-	// Token is used to authenticate the request against the API.
+	// Token is used to authenticate the request against the API. It is replaced when
+	// refreshed with TokenSource, use [Client.CurrentToken] and [Client.SetToken] to read
+	// and change it while requests are in flight.
 	Token string
 
+	// TokenSource, if not nil, is asked for a new token when a request fails with
+	// ErrUnauthorized. The request is then sent once more with the new token. Requests
+	// authenticated with WithToken aren't refreshed.
+	TokenSource TokenSource
+
 	// ServiceKey is the controller token used with the hooks resource.
 	// The hooks resource isn't intended to be used by users, so it requires
 	// a service token rather than a user token.
 	ServiceKey string
+
+	// RetryPolicy controls how requests failing with a transient error are retried.
+	// If nil, every request is attempted once.
+	RetryPolicy *RetryPolicy
+
+	// Strict makes requests fail with the error of the first warning raised by their
+	// response, such as an *APIMismatchError, instead of only recording it.
+	Strict bool
+
+	// OnWarning, if not nil, is called with every new warning, see [Client.Warnings].
+	// It may be called concurrently by requests in flight.
+	OnWarning func(Warning)
+
+	// middlewares wrap HTTPClient, see Use.
+	middlewares []Middleware
+
+	// versionMu guards ControllerAPIVersion and ControllerVersion.
+	versionMu sync.RWMutex
+
+	// warningsMu guards warnings.
+	warningsMu sync.Mutex
+	warnings   []Warning
+
+	// tokenMu guards Token and refresh.
+	tokenMu sync.Mutex
+	refresh *tokenRefresh
 }
 
 // APIVersion is the api version compatible with the SDK.
@@ -95,7 +180,9 @@ type Client struct {
 // compatible. However, using a SDK that is newer or a major version different than the
 // controller is unsafe.
 //
-// If the SDK detects an API version mismatch, it will return ErrAPIMismatch.
+// If the SDK detects an API version mismatch, it records a WarningAPIMismatch warning
+// holding an *APIMismatchError, which matches ErrAPIMismatch with errors.Is. A strict
+// client returns the error instead.
 const APIVersion = "2.3"
 
 var (
@@ -108,14 +195,19 @@ var (
 
 // IsErrAPIMismatch returns true if err is an ErrAPIMismatch, false otherwise
 func IsErrAPIMismatch(err error) bool {
-	return err == ErrAPIMismatch
+	return errors.Is(err, ErrAPIMismatch)
 }
 
 // New creates a new client to communicate with the api.
 // The controllerURL is the url of the controller component, by default drycc.<cluster url>.com
 // verifySSL determines whether or not to verify SSL connections.
 // This should be true unless you know the controller is using untrusted SSL keys.
-func New(verifySSL bool, controllerURL string, token string) (*Client, error) {
+//
+// Connections are kept alive and reused by default; options tune the HTTP transport:
+//
+//	client, err := drycc.New(true, "drycc.test.io", "abc123",
+//	    drycc.WithTimeout(30*time.Second), drycc.WithMaxConnsPerHost(8))
+func New(verifySSL bool, controllerURL string, token string, opts ...Option) (*Client, error) {
 	// preventing issues like missing schemes.
 	if !strings.HasPrefix(controllerURL, "http://") && !strings.HasPrefix(controllerURL, "https://") {
 		controllerURL = "http://" + controllerURL
@@ -125,11 +217,32 @@ func New(verifySSL bool, controllerURL string, token string) (*Client, error) {
 		return nil, err
 	}
 
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	tlsConfig, err := o.tlsConfig(verifySSL)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		HTTPClient:    createHTTPClient(verifySSL),
+		HTTPClient:    createHTTPClient(tlsConfig, o),
+		TLSConfig:     tlsConfig,
 		VerifySSL:     verifySSL,
 		ControllerURL: u,
 		Token:         token,
 		UserAgent:     DefaultUserAgent,
 	}, nil
 }
+
+// Versions returns the API version and platform version of the controller, as reported
+// by the last response.
+func (c *Client) Versions() (apiVersion string, platformVersion string) {
+	c.versionMu.RLock()
+	defer c.versionMu.RUnlock()
+	return c.ControllerAPIVersion, c.ControllerVersion
+}
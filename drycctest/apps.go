@@ -0,0 +1,282 @@
+package drycctest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+var appNameRegexp = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+func (s *Server) routes() {
+	s.authRoutes()
+	s.tokenRoutes()
+	s.userRoutes()
+	s.workspaceRoutes()
+	s.appRoutes()
+	s.ptypeRoutes()
+	s.execRoutes()
+	s.logRoutes()
+	s.portForwardRoutes()
+	s.eventRoutes()
+	s.networkRoutes()
+	s.storageRoutes()
+}
+
+func (s *Server) appRoutes() {
+	s.handle("GET /v2/apps/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		workspace := r.URL.Query().Get("workspace")
+		apps := api.Apps{}
+		for _, app := range s.apps {
+			if workspace == "" || app.app.Workspace == workspace {
+				apps = append(apps, app.app)
+			}
+		}
+		writeList(w, r, apps)
+	})
+	s.handle("POST /v2/apps/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		req := api.AppCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.ID == "" {
+			req.ID = "app-" + randomSuffix()
+		}
+		if !appNameRegexp.MatchString(req.ID) {
+			writeFieldError(w, "id", "App name can only contain a-z (lowercase), 0-9 and hyphens")
+			return
+		}
+		if s.app(req.ID) != nil {
+			writeFieldError(w, "id", "Application with this id already exists.")
+			return
+		}
+		ts := now()
+		app := &appState{
+			app:        api.App{ID: req.ID, Workspace: req.Workspace, Created: ts, Updated: ts, UUID: newUUID()},
+			config:     api.Config{App: req.ID, Created: ts, Updated: ts, UUID: newUUID()},
+			routeRules: map[string]string{},
+		}
+		app.newRelease("Initial release")
+		s.apps = append(s.apps, app)
+		writeJSON(w, http.StatusCreated, app.app)
+	})
+	s.handle("GET /v2/apps/{app}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeJSON(w, http.StatusOK, app.app)
+	})
+	s.handle("PATCH /v2/apps/{app}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.AppUpdateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Workspace != "" {
+			app.app.Workspace = req.Workspace
+			app.app.Updated = now()
+		}
+		writeJSON(w, http.StatusOK, app.app)
+	})
+	s.handle("DELETE /v2/apps/{app}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		remove(&s.apps, func(a *appState) bool { return a == app })
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("POST /v2/apps/{app}/run", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.AppRunRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// builds
+	s.handle("GET /v2/apps/{app}/build/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		build := app.latestBuild()
+		if v := r.URL.Query().Get("version"); v != "" {
+			release := app.release(v)
+			build = nil
+			if release != nil {
+				if i := slices.IndexFunc(app.builds, func(b api.Build) bool { return b.UUID == release.Build }); i >= 0 {
+					build = &app.builds[i]
+				}
+			}
+		}
+		if build == nil {
+			writeDetail(w, http.StatusNotFound, "No Build matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, build)
+	})
+	s.handle("POST /v2/apps/{app}/build/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.CreateBuildRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Image == "" {
+			writeFieldError(w, "image", "This field may not be blank.")
+			return
+		}
+		ts := now()
+		build := api.Build{
+			App: app.app.ID, Image: req.Image, Stack: req.Stack, Procfile: req.Procfile,
+			Dryccfile: req.Dryccfile, Created: ts, Updated: ts, UUID: newUUID(),
+		}
+		first := len(app.builds) == 0
+		app.builds = append(app.builds, build)
+
+		ptypes := []string{api.PtypeWeb}
+		if len(req.Procfile) > 0 {
+			ptypes = ptypes[:0]
+			for ptype := range req.Procfile {
+				ptypes = append(ptypes, ptype)
+			}
+			slices.Sort(ptypes)
+		}
+		for _, ptype := range ptypes {
+			if app.ptype(ptype) == nil {
+				replicas := 0
+				if first && ptype == api.PtypeWeb {
+					replicas = 1
+				}
+				app.ptypes = append(app.ptypes, api.Ptype{Name: ptype, Started: ts})
+				app.scale(ptype, replicas)
+			}
+		}
+		app.newRelease(fmt.Sprintf("%s deployed %s", DefaultUsername, req.Image))
+		writeJSON(w, http.StatusCreated, build)
+	})
+
+	// releases
+	s.handle("GET /v2/apps/{app}/releases/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		releases := slices.Clone(app.releases)
+		slices.Reverse(releases)
+		writeList(w, r, releases)
+	})
+	s.handle("GET /v2/apps/{app}/releases/{version}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		release := app.release(r.PathValue("version"))
+		if release == nil {
+			writeDetail(w, http.StatusNotFound, "No Release matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, release)
+	})
+	s.handle("POST /v2/apps/{app}/releases/deploy/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		targets := map[string]any{}
+		if !readJSON(w, r, &targets) {
+			return
+		}
+		app.rollout()
+		w.WriteHeader(http.StatusCreated)
+	})
+	s.handle("POST /v2/apps/{app}/releases/rollback/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.ReleaseRollback{Version: len(app.releases) - 1}
+		if r.ContentLength != 0 && !readJSON(w, r, &req) {
+			return
+		}
+		if req.Version < 1 || req.Version > len(app.releases) {
+			writeDetail(w, http.StatusBadRequest, "version cannot be below 0")
+			return
+		}
+		target := app.releases[req.Version-1]
+		release := app.newRelease(fmt.Sprintf("%s rolled back to v%d", DefaultUsername, req.Version))
+		release.Build, release.Config = target.Build, target.Config
+		writeJSON(w, http.StatusCreated, api.ReleaseRollback{Version: release.Version, Ptypes: req.Ptypes})
+	})
+
+	// config
+	s.handle("GET /v2/apps/{app}/config/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeJSON(w, http.StatusOK, app.config)
+	})
+	s.handle("POST /v2/apps/{app}/config/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.Config{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		merge := r.URL.Query().Get("merge") != "false"
+		if !merge {
+			app.config.Values = nil
+		}
+		for _, value := range req.Values {
+			same := func(v api.ConfigValue) bool {
+				return v.Name == value.Name && v.Ptype == value.Ptype && v.Group == value.Group
+			}
+			if value.Value == nil {
+				if !remove(&app.config.Values, same) {
+					writeDetail(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s does not exist under values", value.Name))
+					return
+				}
+				continue
+			}
+			if i := slices.IndexFunc(app.config.Values, same); i >= 0 {
+				app.config.Values[i] = value
+			} else {
+				app.config.Values = append(app.config.Values, value)
+			}
+		}
+		for ptype, refs := range req.ValuesRefs {
+			if app.config.ValuesRefs == nil {
+				app.config.ValuesRefs = api.ValuesRefs{}
+			}
+			for _, ref := range refs {
+				if !slices.Contains(app.config.ValuesRefs[ptype], ref) {
+					app.config.ValuesRefs[ptype] = append(app.config.ValuesRefs[ptype], ref)
+				}
+			}
+		}
+		app.configChanged()
+		writeJSON(w, http.StatusCreated, app.config)
+	})
+	s.handle("DELETE /v2/apps/{app}/config/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.Config{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		for ptype, refs := range req.ValuesRefs {
+			app.config.ValuesRefs[ptype] = slices.DeleteFunc(app.config.ValuesRefs[ptype], func(ref string) bool {
+				return slices.Contains(refs, ref)
+			})
+		}
+		app.configChanged()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// newRelease appends a release with the current build and config.
+func (a *appState) newRelease(summary string) *api.Release {
+	ts := now()
+	release := api.Release{
+		App: a.app.ID, State: "succeed", Config: a.config.UUID, Summary: summary,
+		Created: ts, Updated: ts, UUID: newUUID(), Version: len(a.releases) + 1,
+		Conditions: []api.Condition{},
+	}
+	if build := a.latestBuild(); build != nil {
+		release.Build = build.UUID
+	}
+	a.releases = append(a.releases, release)
+	a.rollout()
+	return &a.releases[len(a.releases)-1]
+}
+
+// release returns the release of a version given as "v2" or "2".
+func (a *appState) release(version string) *api.Release {
+	v, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil || v < 1 || v > len(a.releases) {
+		return nil
+	}
+	return &a.releases[v-1]
+}
+
+func (a *appState) latestBuild() *api.Build {
+	if len(a.builds) == 0 {
+		return nil
+	}
+	return &a.builds[len(a.builds)-1]
+}
+
+func (a *appState) configChanged() {
+	a.config.UUID = newUUID()
+	a.config.Updated = now()
+	a.newRelease(fmt.Sprintf("%s changed config", DefaultUsername))
+}
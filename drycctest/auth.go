@@ -0,0 +1,96 @@
+package drycctest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// DefaultPassword is the password of DefaultUsername.
+const DefaultPassword = "drycctest-password"
+
+// loginState tracks a login started with /v2/auth/login/.
+type loginState struct {
+	// redirect is where the browser is sent once logged in, if anywhere.
+	redirect string
+	// done is set once the user has logged in.
+	done bool
+}
+
+// public reports whether path is served without a token.
+func public(path string) bool {
+	for _, prefix := range []string{"/v2/auth/login/", "/v2/auth/token/", "/v2/login/"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) authRoutes() {
+	s.handle("POST /v2/auth/login/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		key := strings.ReplaceAll(newUUID(), "-", "")
+		req := api.AuthLoginRequest{}
+		if r.ContentLength != 0 {
+			if !readJSON(w, r, &req) {
+				return
+			}
+			if req.Username != DefaultUsername || req.Password != DefaultPassword {
+				writeJSON(w, http.StatusBadRequest, map[string][]string{
+					"non_field_errors": {"Unable to log in with provided credentials."},
+				})
+				return
+			}
+			s.logins[key] = &loginState{done: true}
+			writeJSON(w, http.StatusOK, api.AuthLoginResponse{Key: key})
+			return
+		}
+
+		// Without credentials, the user is sent to log in with a browser.
+		s.logins[key] = &loginState{redirect: r.URL.Query().Get("redirect_uri")}
+		w.Header().Set("Location", "/v2/login/drycc/?key="+key)
+		w.WriteHeader(http.StatusFound)
+	})
+	s.handle("GET /v2/login/drycc/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		key := r.URL.Query().Get("key")
+		login := s.logins[key]
+		if login == nil {
+			writeDetail(w, http.StatusNotFound, "Not found.")
+			return
+		}
+		login.done = true
+		if login.redirect == "" {
+			fmt.Fprintln(w, "Logged in, you may close this window.")
+			return
+		}
+		u, err := url.Parse(login.redirect)
+		if err != nil {
+			writeDetail(w, http.StatusBadRequest, "Invalid redirect URI.")
+			return
+		}
+		q := u.Query()
+		q.Set("key", key)
+		u.RawQuery = q.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	})
+	s.handle("GET /v2/auth/token/{key}/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		key := r.PathValue("key")
+		login := s.logins[key]
+		if login == nil || !login.done {
+			writeDetail(w, http.StatusNotFound, "Not found.")
+			return
+		}
+		delete(s.logins, key)
+		// Logins share the oldest token, if any, so that it can be compared with Token.
+		if len(s.tokens) == 0 {
+			s.createToken(r.URL.Query().Get("alias"))
+		}
+		writeJSON(w, http.StatusOK, api.AuthTokenResponse{Token: s.tokens[0].key, Username: DefaultUsername})
+	})
+	s.handle("GET /v2/auth/whoami/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		writeJSON(w, http.StatusOK, s.user(DefaultUsername))
+	})
+}
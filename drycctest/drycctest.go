@@ -0,0 +1,314 @@
+// Package drycctest provides an in-memory fake Drycc controller for tests.
+//
+// The fake controller speaks the same /v2 routes as the real one and keeps its state in
+// memory, so code using the SDK can be tested without a cluster:
+//
+//	server := drycctest.NewServer()
+//	defer server.Close()
+//
+//	client, err := server.Client()
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	app, err := apps.New(client, "example-go", "test")
+//
+// It covers authentication, tokens, users, workspaces, apps, builds, releases, config,
+// process types, pods, events, domains, certificates, volumes, resources and routes.
+// Commands run in pods are handled by a function set with SetExec, ports of pods are
+// connected by one set with SetPortForward, and pods stream the lines given to Log. Other
+// behaviour that needs a cluster is not implemented.
+package drycctest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	drycctime "github.com/drycc/controller-sdk-go/pkg/time"
+)
+
+const (
+	// DefaultToken is the token accepted by a Server unless Token is changed.
+	DefaultToken = "drycctest-token"
+	// DefaultUsername is the superuser the token of a Server belongs to.
+	DefaultUsername = "drycctest"
+	// PlatformVersion is the platform version reported by a Server.
+	PlatformVersion = "drycctest"
+)
+
+// Server is an in-memory fake Drycc controller. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	tokens     []tokenState
+	users      api.Users
+	workspaces []api.Workspace
+	apps       []*appState
+	services   []serviceState
+	logins     map[string]*loginState
+	exec       ExecFunc
+	ports      PortFunc
+	// logs is closed and replaced when logs or pods change, waking up log streams.
+	logs chan struct{}
+	// logStreams is incremented to close the log streams.
+	logStreams int
+	mux        *http.ServeMux
+	// streams serves the websockets, which are long-lived and so don't hold mu.
+	streams *http.ServeMux
+}
+
+// appState holds everything belonging to an app.
+type appState struct {
+	app        api.App
+	builds     []api.Build
+	releases   []api.Release
+	config     api.Config
+	ptypes     []api.Ptype
+	pods       api.PodsList
+	domains    api.Domains
+	certs      []api.Cert
+	volumes    api.Volumes
+	resources  api.Resources
+	routes     api.Routes
+	routeRules map[string]string
+	events     []podEvent
+	// logs holds the lines logged by each pod.
+	logs map[string][]string
+}
+
+type serviceState struct {
+	service api.ResourceService
+	plans   api.ResourcePlans
+}
+
+// NewServer starts a fake controller. It must be closed with Close.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a fake controller that isn't started yet, so that it can be
+// seeded or configured, for example with TLS, before calling Start or StartTLS.
+func NewUnstartedServer() *Server {
+	s := &Server{
+		logins: map[string]*loginState{},
+		services: []serviceState{{
+			service: api.ResourceService{ID: "postgresql", Name: "postgresql", Updateable: true},
+			plans: api.ResourcePlans{
+				{ID: "postgresql-standard-10", Name: "standard-10", Description: "10 connections"},
+				{ID: "postgresql-standard-20", Name: "standard-20", Description: "20 connections"},
+			},
+		}},
+	}
+	s.tokens = []tokenState{newToken(DefaultToken, "")}
+	s.users = api.Users{newUser(1, DefaultUsername)}
+	s.users[0].IsSuperuser, s.users[0].IsStaff = true, true
+	s.logs = make(chan struct{})
+	s.mux = http.NewServeMux()
+	s.streams = http.NewServeMux()
+	s.routes()
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetToken changes the token the controller accepts, invalidating the previous ones.
+func (s *Server) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = []tokenState{newToken(token, "")}
+}
+
+// Token returns the oldest token the controller accepts, or an empty string if every
+// token was revoked.
+func (s *Server) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.tokens) == 0 {
+		return ""
+	}
+	return s.tokens[0].key
+}
+
+// Client returns a client authenticated against the controller.
+func (s *Server) Client(opts ...drycc.Option) (*drycc.Client, error) {
+	if s.Server.TLS != nil {
+		opts = append([]drycc.Option{drycc.WithTransport(s.Server.Client().Transport)}, opts...)
+	}
+	return drycc.New(true, s.URL, s.Token(), opts...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DRYCC_API_VERSION", drycc.APIVersion)
+	w.Header().Set("DRYCC_PLATFORM_VERSION", PlatformVersion)
+
+	switch r.URL.Path {
+	case "/healthz":
+		w.WriteHeader(http.StatusOK)
+		return
+	case "/v2/":
+		// The SDK checks connections by expecting a 401 here.
+		writeDetail(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return
+	}
+
+	s.mu.Lock()
+	if !public(r.URL.Path) && !s.authenticate(r) {
+		s.mu.Unlock()
+		writeDetail(w, http.StatusUnauthorized, "Invalid token.")
+		return
+	}
+	if _, pattern := s.streams.Handler(r); pattern != "" {
+		s.mu.Unlock()
+		s.streams.ServeHTTP(w, r)
+		return
+	}
+	defer s.mu.Unlock()
+	s.mux.ServeHTTP(w, r)
+	if r.Method != http.MethodGet {
+		// Pods may have changed.
+		s.broadcast()
+	}
+}
+
+// handle registers a handler for pattern. The app is looked up for patterns with an
+// {app} wildcard, answering 404 if it doesn't exist.
+func (s *Server) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request, app *appState)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		var app *appState
+		if id := r.PathValue("app"); id != "" {
+			if app = s.app(id); app == nil {
+				writeDetail(w, http.StatusNotFound, "No App matches the given query.")
+				return
+			}
+		}
+		handler(w, r, app)
+	})
+}
+
+func (s *Server) app(id string) *appState {
+	for _, app := range s.apps {
+		if app.app.ID == id {
+			return app
+		}
+	}
+	return nil
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeDetail writes an error response with a detail message, as the controller does.
+func writeDetail(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+// writeFieldError writes a validation error for a field.
+func writeFieldError(w http.ResponseWriter, field, msg string) {
+	writeJSON(w, http.StatusBadRequest, map[string][]string{field: {msg}})
+}
+
+// readJSON decodes the request body into v, answering 400 if it's invalid.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeDetail(w, http.StatusBadRequest, fmt.Sprintf("JSON parse error - %v", err))
+		return false
+	}
+	return true
+}
+
+// writeList writes a page of items, honouring the limit and offset query parameters
+// and linking to the neighbouring pages like the controller does.
+func writeList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+
+	link := func(offset int) *string {
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+		if r.TLS != nil {
+			u.Scheme = "https"
+		}
+		q := r.URL.Query()
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("offset", strconv.Itoa(offset))
+		u.RawQuery = q.Encode()
+		link := u.String()
+		return &link
+	}
+	var next, previous *string
+	if end < len(items) {
+		next = link(end)
+	}
+	if offset > 0 {
+		previous = link(max(offset-limit, 0))
+	}
+
+	results := items[offset:end]
+	if results == nil {
+		results = []T{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":    len(items),
+		"next":     next,
+		"previous": previous,
+		"results":  results,
+	})
+}
+
+// remove deletes the first item matching f, reporting whether there was one.
+func remove[S ~[]T, T any](items *S, f func(T) bool) bool {
+	i := slices.IndexFunc(*items, f)
+	if i < 0 {
+		return false
+	}
+	*items = slices.Delete(*items, i, i+1)
+	return true
+}
+
+func now() string {
+	return time.Now().UTC().Format(drycctime.DryccDatetimeFormat)
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func randomSuffix() string {
+	b := make([]byte, 3)
+	rand.Read(b)
+	return hex.EncodeToString(b)[:5]
+}
+
+func (s *Server) lookup(appID string) (*appState, error) {
+	app := s.app(appID)
+	if app == nil {
+		return nil, fmt.Errorf("drycctest: app %s does not exist", appID)
+	}
+	return app, nil
+}
@@ -0,0 +1,482 @@
+package drycctest_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/auth"
+	"github.com/drycc/controller-sdk-go/builds"
+	"github.com/drycc/controller-sdk-go/certs"
+	"github.com/drycc/controller-sdk-go/config"
+	"github.com/drycc/controller-sdk-go/domains"
+	"github.com/drycc/controller-sdk-go/drycctest"
+	"github.com/drycc/controller-sdk-go/events"
+	"github.com/drycc/controller-sdk-go/ps"
+	"github.com/drycc/controller-sdk-go/pts"
+	"github.com/drycc/controller-sdk-go/releases"
+	"github.com/drycc/controller-sdk-go/resources"
+	"github.com/drycc/controller-sdk-go/routes"
+	"github.com/drycc/controller-sdk-go/tokens"
+	"github.com/drycc/controller-sdk-go/users"
+	"github.com/drycc/controller-sdk-go/volumes"
+	"github.com/drycc/controller-sdk-go/workspaces"
+)
+
+func newClient(t *testing.T) (*drycctest.Server, *drycc.Client) {
+	t.Helper()
+
+	server := drycctest.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+// newApp creates an app with a first build, so that it has a running web process.
+func newApp(t *testing.T, c *drycc.Client, appID string) {
+	t.Helper()
+
+	if _, err := apps.New(c, appID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builds.New(c, appID, "example/go:latest", "container", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConnection(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+
+	if err := client.CheckConnection(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Healthcheck(); err != nil {
+		t.Fatal(err)
+	}
+	if _, platform := client.Versions(); platform != drycctest.PlatformVersion {
+		t.Errorf("Expected %s, Got %s", drycctest.PlatformVersion, platform)
+	}
+
+	server.SetToken("another")
+	if _, _, err := apps.List(client, "", 100); !errors.Is(err, drycc.ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrUnauthorized, err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+
+	key, err := auth.Login(client, drycctest.DefaultUsername, drycctest.DefaultPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := auth.Token(client, key, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != server.Token() || token.Username != drycctest.DefaultUsername {
+		t.Errorf("Expected %s of %s, Got %v", server.Token(), drycctest.DefaultUsername, token)
+	}
+	// Keys are exchanged once.
+	if _, err := auth.Token(client, key, "test"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrNotFound{}, err)
+	}
+
+	if _, err := auth.Login(client, drycctest.DefaultUsername, "wrong"); !errors.Is(err, drycc.ErrLogin) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrLogin, err)
+	}
+
+	user, err := auth.Whoami(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != drycctest.DefaultUsername {
+		t.Errorf("Expected %s, Got %s", drycctest.DefaultUsername, user.Username)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+
+	created, err := tokens.Create(client, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _, err := tokens.List(client, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[1].UUID != created.UUID || list[1].Alias != "ci" {
+		t.Fatalf("Expected the created token to be listed, Got %v", list)
+	}
+
+	// Every token is accepted until it is deleted.
+	if _, err := auth.WhoamiWithContext(drycc.WithToken(context.Background(), created.Token), client); err != nil {
+		t.Error(err)
+	}
+	if err := tokens.Delete(client, list[0].UUID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.Whoami(client); !errors.Is(err, drycc.ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrUnauthorized, err)
+	}
+	if server.Token() != created.Token {
+		t.Errorf("Expected %s, Got %s", created.Token, server.Token())
+	}
+}
+
+func TestUsers(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+	server.AddUser("test")
+
+	list, count, err := users.List(client, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || !list[0].IsSuperuser || list[1].Username != "test" || list[1].IsSuperuser {
+		t.Fatalf("Expected a superuser and a user, Got %v", list)
+	}
+
+	if err := users.Deactivate(client, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.SetSuperuser(client, "test", true); err != nil {
+		t.Fatal(err)
+	}
+	user, err := users.Get(client, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.IsActive || !user.IsSuperuser {
+		t.Errorf("Expected an inactive superuser, Got %v", user)
+	}
+
+	if _, err := users.Get(client, "missing"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound, Got %v", err)
+	}
+	if err := users.Activate(client, "missing"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound, Got %v", err)
+	}
+}
+
+func TestWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+
+	if _, err := workspaces.Create(client, "test", "test@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	workspace, err := workspaces.Update(client, "test", "ops@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspace.Email != "ops@example.com" {
+		t.Errorf("Expected %s, Got %s", "ops@example.com", workspace.Email)
+	}
+	if err = workspaces.Delete(client, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = workspaces.Get(client, "test"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound, Got %v", err)
+	}
+}
+
+func TestApps(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+
+	for _, id := range []string{"example-a", "example-b", "example-c"} {
+		if _, err := apps.New(client, id, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := apps.New(client, "example-a", "test"); !errors.Is(err, drycc.ErrDuplicateApp) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrDuplicateApp, err)
+	}
+	if _, err := apps.New(client, "Example", "test"); !errors.Is(err, drycc.ErrInvalidAppName) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrInvalidAppName, err)
+	}
+
+	// Pages of one app each exercise the pagination links.
+	all, err := drycc.ListAll[api.App](context.Background(), client, "/v2/apps/", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected %d, Got %d", 3, len(all))
+	}
+
+	if err = apps.Transfer(client, "example-b", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if app, err := apps.Get(client, "example-b"); err != nil || app.Workspace != "other" {
+		t.Errorf("Expected %s, Got %v (%v)", "other", app.Workspace, err)
+	}
+	if err = apps.Delete(client, "example-c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = apps.Get(client, "example-c"); !errors.As(err, &drycc.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound, Got %v", err)
+	}
+}
+
+func TestReleases(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	if _, err := config.Set(client, "example-go", api.Config{Values: []api.ConfigValue{
+		{Group: "global", ConfigVar: api.ConfigVar{Name: "DEBUG", Value: "true"}},
+	}}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	list, count, err := releases.List(client, "example-go", "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || list[0].Version != 3 {
+		t.Fatalf("Expected 3 releases, newest first, Got %v", list)
+	}
+
+	version, err := releases.Rollback(client, "example-go", "", -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 4 {
+		t.Errorf("Expected %d, Got %d", 4, version)
+	}
+	release, err := releases.Get(client, "example-go", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Config != list[1].Config {
+		t.Errorf("Expected %s, Got %s", list[1].Config, release.Config)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	set := func(name string, value any) {
+		t.Helper()
+		if _, err := config.Set(client, "example-go", api.Config{Values: []api.ConfigValue{
+			{Ptype: "web", ConfigVar: api.ConfigVar{Name: name, Value: value}},
+		}}, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set("PORT", "8000")
+	set("DEBUG", "true")
+	set("PORT", "9000")
+	set("DEBUG", nil)
+
+	actual, err := config.List(client, "example-go", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []api.ConfigValue{{Ptype: "web", ConfigVar: api.ConfigVar{Name: "PORT", Value: "9000"}}}
+	if !reflect.DeepEqual(expected, actual.Values) {
+		t.Errorf("Expected %v, Got %v", expected, actual.Values)
+	}
+
+	_, err = config.Set(client, "example-go", api.Config{Values: []api.ConfigValue{
+		{ConfigVar: api.ConfigVar{Name: "MISSING"}},
+	}}, true)
+	if !errors.As(err, &drycc.ErrUnprocessable{}) {
+		t.Errorf("Expected ErrUnprocessable, Got %v", err)
+	}
+}
+
+func TestScale(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	if err := pts.Scale(client, "example-go", map[string]int{"web": 3}); err != nil {
+		t.Fatal(err)
+	}
+	ptypes, _, err := pts.List(client, "example-go", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ptypes) != 1 || ptypes[0].Ready != "3/3" || ptypes[0].AvailableReplicas != 3 {
+		t.Errorf("Expected web with 3/3 ready, Got %v", ptypes)
+	}
+
+	pods, _, err := ps.List(client, "example-go", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 3 {
+		t.Fatalf("Expected %d, Got %d", 3, len(pods))
+	}
+	if err = ps.Delete(client, "example-go", pods[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	current, err := server.Pods("example-go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current) != 3 || current[0].Name == pods[0].Name {
+		t.Errorf("Expected pod %s to be replaced, Got %v", pods[0].Name, current)
+	}
+
+	if err = pts.Scale(client, "example-go", map[string]int{"worker": 1}); !errors.Is(err, drycc.ErrPodNotFound) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrPodNotFound, err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	server, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	for _, pod := range []string{"example-go-web-111", "example-go-worker-222"} {
+		if err := server.AddEvent("example-go", pod, api.AppEvent{Reason: "Started", Message: pod}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	podEvents, _, err := events.ListPodEvents(client, "example-go", "example-go-web-111", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(podEvents) != 1 || podEvents[0].Message != "example-go-web-111" || podEvents[0].Created == "" {
+		t.Errorf("Expected the event of example-go-web-111, Got %v", podEvents)
+	}
+	ptypeEvents, _, err := events.ListPtypeEvents(client, "example-go", "worker", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ptypeEvents) != 1 || ptypeEvents[0].Message != "example-go-worker-222" {
+		t.Errorf("Expected the event of example-go-worker-222, Got %v", ptypeEvents)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	if _, err := domains.New(client, "example-go", "example.com", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := domains.New(client, "example-go", "example.com", "web"); !errors.Is(err, drycc.ErrDuplicateDomain) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrDuplicateDomain, err)
+	}
+	if _, err := certs.New(client, "example-go", "cert", "key", "example"); err != nil {
+		t.Fatal(err)
+	}
+	if err := certs.Attach(client, "example-go", "example", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := certs.Get(client, "example-go", "example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"example.com"}, cert.Domains) {
+		t.Errorf("Expected %v, Got %v", []string{"example.com"}, cert.Domains)
+	}
+	if err = certs.Detach(client, "example-go", "example", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err = domains.Delete(client, "example-go", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = routes.New(client, "example-go", "example", "HTTPRoute", api.BackendRefRequest{Kind: "Service", Name: "example-go-web", Port: 80}); err != nil {
+		t.Fatal(err)
+	}
+	if err = routes.AttachGateway(client, "example-go", "example", 80, "gateway"); err != nil {
+		t.Fatal(err)
+	}
+	rules := `[{"backendRefs":[{"name":"example-go-web","port":80}]}]`
+	if err = routes.SetRule(client, "example-go", "example", rules); err != nil {
+		t.Fatal(err)
+	}
+	list, _, err := routes.List(client, "example-go", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || !reflect.DeepEqual([]api.ParentRef{{Name: "gateway", Port: 80}}, list[0].ParentRefs) {
+		t.Errorf("Expected route attached to gateway, Got %v", list)
+	}
+	if err = routes.Delete(client, "example-go", "example"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	newApp(t, client, "example-go")
+
+	if _, err := volumes.Create(client, "example-go", api.Volume{Name: "data", Size: "1G"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := volumes.Expand(client, "example-go", api.Volume{Name: "data", Size: "2G"}); err != nil {
+		t.Fatal(err)
+	}
+	volume, err := volumes.Mount(client, "example-go", "data", api.Volume{Path: map[string]any{"web": "/data"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Size != "2G" || volume.Path["web"] != "/data" {
+		t.Errorf("Expected a 2G volume mounted on /data, Got %v", volume)
+	}
+	if err = volumes.Delete(client, "example-go", "data"); err == nil {
+		t.Error("Expected an error deleting a mounted volume")
+	}
+
+	services, _, err := resources.Services(client, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plans, _, err := resources.Plans(client, services[0].Name, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := services[0].Name + ":" + plans[0].Name
+	if _, err = resources.Create(client, "example-go", api.Resource{Name: "db", Plan: plan}); err != nil {
+		t.Fatal(err)
+	}
+	resource, err := resources.Binding(client, "example-go", "db", api.ResourceBinding{BindAction: "bind"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resource.Binding != "Ready" || resource.Data["password"] == nil {
+		t.Errorf("Expected a bound resource with credentials, Got %v", resource)
+	}
+	if _, err = resources.Binding(client, "example-go", "db", api.ResourceBinding{BindAction: "unbind"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = resources.Delete(client, "example-go", "db"); err != nil {
+		t.Fatal(err)
+	}
+}
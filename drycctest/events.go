@@ -0,0 +1,49 @@
+package drycctest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// podEvent is an event of a pod.
+type podEvent struct {
+	pod   string
+	event api.AppEvent
+}
+
+func (s *Server) eventRoutes() {
+	s.handle("GET /v2/apps/{app}/events/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		query := r.URL.Query()
+		events := api.AppEvents{}
+		for _, e := range app.events {
+			// Process types are selected by the name of their deployment, which prefixes
+			// the names of their pods.
+			if pod := query.Get("pod_name"); pod != "" && e.pod != pod {
+				continue
+			}
+			if ptype := query.Get("ptype"); ptype != "" && !strings.HasPrefix(e.pod, ptype+"-") {
+				continue
+			}
+			events = append(events, e.event)
+		}
+		writeList(w, r, events)
+	})
+}
+
+// AddEvent records an event of a pod, listed by the events of the pod and of its process
+// type. An empty Created is set to the current time.
+func (s *Server) AddEvent(appID, podID string, event api.AppEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return err
+	}
+	if event.Created == "" {
+		event.Created = now()
+	}
+	app.events = append(app.events, podEvent{podID, event})
+	return nil
+}
@@ -0,0 +1,158 @@
+package drycctest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+// The streams of the exec websocket, as the controller relays them.
+const (
+	streamStdin  byte = 0
+	streamStdout byte = 1
+	streamStderr byte = 2
+	streamStatus byte = 3
+	streamResize byte = 4
+	streamClose  byte = 255
+)
+
+// Exec is a command run in a pod of a Server, see SetExec.
+type Exec struct {
+	AppID   string
+	PodID   string
+	Command api.Command
+	// Stdin reads the standard input sent by the client, until it closes it.
+	Stdin io.Reader
+	// Stdout and Stderr send output to the client.
+	Stdout io.Writer
+	Stderr io.Writer
+	// Resize receives the terminal sizes sent by the client. Sizes are dropped while
+	// the channel is full.
+	Resize <-chan api.TerminalSize
+	// Context is canceled when the client disconnects.
+	Context context.Context
+}
+
+// ExecFunc runs a command in a pod and returns its exit code.
+type ExecFunc func(e *Exec) int
+
+// SetExec sets the function running the commands sent to the exec websocket of pods.
+// Without one, commands fail as if the executable wasn't found.
+func (s *Server) SetExec(fn ExecFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exec = fn
+}
+
+func (s *Server) execRoutes() {
+	s.streams.Handle("GET /v2/apps/{app}/pods/{pod}/exec/{$}", websocket.Handler(func(conn *websocket.Conn) {
+		defer conn.Close()
+		r := conn.Request()
+		e := &Exec{AppID: r.PathValue("app"), PodID: r.PathValue("pod")}
+		if err := websocket.JSON.Receive(conn, &e.Command); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		fn := s.exec
+		found := s.hasPod(e.AppID, e.PodID)
+		s.mu.Unlock()
+		out := &execConn{conn: conn}
+		switch {
+		case !found:
+			out.status(-1, fmt.Sprintf("pods %q not found", e.PodID))
+			return
+		case fn == nil:
+			out.status(-1, "executable file not found in $PATH")
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stdin, stdinW := io.Pipe()
+		resize := make(chan api.TerminalSize, 1)
+		e.Stdin, e.Resize, e.Context = stdin, resize, ctx
+		e.Stdout, e.Stderr = out.stream(streamStdout), out.stream(streamStderr)
+		go func() {
+			defer cancel()
+			defer stdinW.Close()
+			for {
+				var msg []byte
+				if err := websocket.Message.Receive(conn, &msg); err != nil || len(msg) == 0 {
+					return
+				}
+				switch msg[0] {
+				case streamStdin:
+					stdinW.Write(msg[1:])
+				case streamClose:
+					stdinW.Close()
+				case streamResize:
+					size := api.TerminalSize{}
+					if json.Unmarshal(msg[1:], &size) == nil {
+						select {
+						case resize <- size:
+						default:
+						}
+					}
+				}
+			}
+		}()
+
+		code := fn(e)
+		stdin.Close()
+		out.status(code, "")
+	}))
+}
+
+// execConn sends the output of a command, one stream at a time.
+type execConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *execConn) send(stream byte, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return websocket.Message.Send(c.conn, append([]byte{stream}, data...))
+}
+
+func (c *execConn) stream(stream byte) io.Writer {
+	return streamWriter{c, stream}
+}
+
+// status reports the exit code of the command, or a failure to run it with msg.
+func (c *execConn) status(code int, msg string) {
+	status := map[string]any{"status": "Success"}
+	if code < 0 {
+		status = map[string]any{"status": "Failure", "message": msg}
+	} else if code > 0 {
+		status = map[string]any{
+			"status":  "Failure",
+			"message": fmt.Sprintf("command terminated with non-zero exit code: exit status %d", code),
+			"reason":  "NonZeroExitCode",
+			"details": map[string]any{
+				"causes": []map[string]string{{"reason": "ExitCode", "message": strconv.Itoa(code)}},
+			},
+		}
+	}
+	data, _ := json.Marshal(status)
+	c.send(streamStatus, data)
+}
+
+type streamWriter struct {
+	c      *execConn
+	stream byte
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if err := w.c.send(w.stream, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
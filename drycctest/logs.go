@@ -0,0 +1,117 @@
+package drycctest
+
+import (
+	"time"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+// Log appends lines to the logs of a pod, sending them to the streams following it. The
+// lines are prefixed with the current time, as the controller does.
+func (s *Server) Log(appID, podID string, lines ...string) error {
+	return s.LogAt(appID, podID, time.Now(), lines...)
+}
+
+// LogAt is like [Server.Log] but prefixes the lines with t, or with nothing if t is zero.
+func (s *Server) LogAt(appID, podID string, t time.Time, lines ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return err
+	}
+	if app.logs == nil {
+		app.logs = map[string][]string{}
+	}
+	for _, line := range lines {
+		if !t.IsZero() {
+			line = t.UTC().Format(time.RFC3339Nano) + " " + line
+		}
+		app.logs[podID] = append(app.logs[podID], line)
+	}
+	s.broadcast()
+	return nil
+}
+
+// CloseLogStreams disconnects the log streams, so that tests can simulate network
+// failures.
+func (s *Server) CloseLogStreams() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logStreams++
+	s.broadcast()
+}
+
+// broadcast wakes up the log streams. It is called with mu held.
+func (s *Server) broadcast() {
+	close(s.logs)
+	s.logs = make(chan struct{})
+}
+
+// podLogs returns the lines logged by a pod, and whether it exists. It is called with mu
+// held.
+func (s *Server) podLogs(appID, podID string) ([]string, bool) {
+	if !s.hasPod(appID, podID) {
+		return nil, false
+	}
+	return s.app(appID).logs[podID], true
+}
+
+func (s *Server) logRoutes() {
+	s.streams.Handle("GET /v2/apps/{app}/pods/{pod}/logs/{$}", websocket.Handler(func(conn *websocket.Conn) {
+		defer conn.Close()
+		r := conn.Request()
+		appID, podID := r.PathValue("app"), r.PathValue("pod")
+		req := api.PodLogsRequest{}
+		if err := websocket.JSON.Receive(conn, &req); err != nil {
+			return
+		}
+		// Clients send nothing more, so reading only detects disconnections.
+		gone := make(chan struct{})
+		go func() {
+			defer close(gone)
+			var msg []byte
+			for websocket.Message.Receive(conn, &msg) == nil {
+			}
+		}()
+
+		s.mu.Lock()
+		generation := s.logStreams
+		lines, _ := s.podLogs(appID, podID)
+		s.mu.Unlock()
+		sent := 0
+		if req.Lines > 0 {
+			sent = max(len(lines)-req.Lines, 0)
+		}
+		for {
+			for _, line := range lines[sent:] {
+				if websocket.Message.Send(conn, line+"\n") != nil {
+					return
+				}
+			}
+			sent = len(lines)
+			if !req.Follow {
+				return
+			}
+
+			s.mu.Lock()
+			changed := s.logs
+			s.mu.Unlock()
+			select {
+			case <-changed:
+			case <-gone:
+				return
+			}
+
+			s.mu.Lock()
+			var found bool
+			lines, found = s.podLogs(appID, podID)
+			closed := s.logStreams != generation
+			s.mu.Unlock()
+			if !found || closed {
+				return
+			}
+		}
+	}))
+}
@@ -0,0 +1,207 @@
+package drycctest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/drycc/controller-sdk-go/api"
+	drycctime "github.com/drycc/controller-sdk-go/pkg/time"
+)
+
+func (s *Server) networkRoutes() {
+	// domains
+	s.handle("GET /v2/apps/{app}/domains/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.domains)
+	})
+	s.handle("POST /v2/apps/{app}/domains/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.DomainCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if slices.ContainsFunc(app.domains, func(d api.Domain) bool { return d.Domain == req.Domain }) {
+			writeFieldError(w, "domain", "Domain is already in use by another application")
+			return
+		}
+		if req.Ptype == "" {
+			req.Ptype = api.PtypeWeb
+		}
+		ts := now()
+		domain := api.Domain{App: app.app.ID, Domain: req.Domain, Ptype: req.Ptype, Created: ts, Updated: ts}
+		app.domains = append(app.domains, domain)
+		writeJSON(w, http.StatusCreated, domain)
+	})
+	s.handle("DELETE /v2/apps/{app}/domains/{domain}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		if !remove(&app.domains, func(d api.Domain) bool { return d.Domain == r.PathValue("domain") }) {
+			writeDetail(w, http.StatusNotFound, "No Domain matches the given query.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// certs
+	s.handle("GET /v2/apps/{app}/certs/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.certs)
+	})
+	s.handle("POST /v2/apps/{app}/certs/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.CertCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Certificate == "" || req.Key == "" {
+			writeFieldError(w, "certificate", "This field may not be blank.")
+			return
+		}
+		if app.cert(req.Name) != nil {
+			writeFieldError(w, "name", "certificate with this name already exists.")
+			return
+		}
+		ts, starts := now(), time.Now().UTC()
+		expires := starts.AddDate(1, 0, 0)
+		cert := api.Cert{
+			App: app.app.ID, Name: req.Name, CommonName: req.Name, Created: ts, Updated: ts,
+			Starts: drycctime.Time{Time: &starts}, Expires: drycctime.Time{Time: &expires}, ID: len(app.certs) + 1,
+		}
+		app.certs = append(app.certs, cert)
+		writeJSON(w, http.StatusCreated, cert)
+	})
+	s.handle("GET /v2/apps/{app}/certs/{name}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		cert := app.cert(r.PathValue("name"))
+		if cert == nil {
+			writeDetail(w, http.StatusNotFound, "No Certificate matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, cert)
+	})
+	s.handle("DELETE /v2/apps/{app}/certs/{name}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		if !remove(&app.certs, func(c api.Cert) bool { return c.Name == r.PathValue("name") }) {
+			writeDetail(w, http.StatusNotFound, "No Certificate matches the given query.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("POST /v2/apps/{app}/certs/{name}/domain/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		cert := app.cert(r.PathValue("name"))
+		if cert == nil {
+			writeDetail(w, http.StatusNotFound, "No Certificate matches the given query.")
+			return
+		}
+		req := api.CertAttachRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if !slices.ContainsFunc(app.domains, func(d api.Domain) bool { return d.Domain == req.Domain }) {
+			writeDetail(w, http.StatusNotFound, "No Domain matches the given query.")
+			return
+		}
+		if !slices.Contains(cert.Domains, req.Domain) {
+			cert.Domains = append(cert.Domains, req.Domain)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	s.handle("DELETE /v2/apps/{app}/certs/{name}/domain/{domain}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		cert := app.cert(r.PathValue("name"))
+		if cert == nil || !remove(&cert.Domains, func(d string) bool { return d == r.PathValue("domain") }) {
+			writeDetail(w, http.StatusNotFound, "No Certificate matches the given query.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// routes
+	s.handle("GET /v2/apps/{app}/routes/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.routes)
+	})
+	s.handle("POST /v2/apps/{app}/routes/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.RouteCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if app.route(req.Name) != nil {
+			writeFieldError(w, "name", "route with this name already exists.")
+			return
+		}
+		ts := now()
+		route := api.Route{App: app.app.ID, Name: req.Name, Kind: req.Kind, Created: ts, Updated: ts, UUID: newUUID()}
+		for _, rule := range req.Rules {
+			route.Rules = append(route.Rules, api.RouteRule{"backendRefs": rule.BackendRefs})
+		}
+		app.routes = append(app.routes, route)
+		w.WriteHeader(http.StatusCreated)
+	})
+	s.handle("PATCH /v2/apps/{app}/routes/{name}/{action}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		route := app.route(r.PathValue("name"))
+		if route == nil {
+			writeDetail(w, http.StatusNotFound, "No Route matches the given query.")
+			return
+		}
+		req := api.RouteAttachRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		parent := api.ParentRef{Name: req.Gateway, Port: req.Port}
+		switch r.PathValue("action") {
+		case "attach":
+			if !slices.Contains(route.ParentRefs, parent) {
+				route.ParentRefs = append(route.ParentRefs, parent)
+			}
+		case "detach":
+			if !remove(&route.ParentRefs, func(p api.ParentRef) bool { return p == parent }) {
+				writeDetail(w, http.StatusBadRequest, fmt.Sprintf("port %d of gateway %s is not attached", req.Port, req.Gateway))
+				return
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		route.Updated = now()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("GET /v2/apps/{app}/routes/{name}/rules/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		if app.route(r.PathValue("name")) == nil {
+			writeDetail(w, http.StatusNotFound, "No Route matches the given query.")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, app.routeRules[r.PathValue("name")])
+	})
+	s.handle("PUT /v2/apps/{app}/routes/{name}/rules/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		if app.route(r.PathValue("name")) == nil {
+			writeDetail(w, http.StatusNotFound, "No Route matches the given query.")
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeDetail(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		app.routeRules[r.PathValue("name")] = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("DELETE /v2/apps/{app}/routes/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		if !remove(&app.routes, func(route api.Route) bool { return route.Name == r.PathValue("name") }) {
+			writeDetail(w, http.StatusNotFound, "No Route matches the given query.")
+			return
+		}
+		delete(app.routeRules, r.PathValue("name"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (a *appState) cert(name string) *api.Cert {
+	i := slices.IndexFunc(a.certs, func(c api.Cert) bool { return c.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &a.certs[i]
+}
+
+func (a *appState) route(name string) *api.Route {
+	i := slices.IndexFunc(a.routes, func(r api.Route) bool { return r.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &a.routes[i]
+}
@@ -0,0 +1,86 @@
+package drycctest
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+// The channels of the port-forward websocket, as the controller relays them.
+const (
+	portData  byte = 0
+	portError byte = 1
+)
+
+// PortFunc connects to a port of a pod, see SetPortForward.
+type PortFunc func(appID, podID string, port int) (net.Conn, error)
+
+// SetPortForward sets the function connecting the port-forward websocket of pods to their
+// ports. Without one, connections are refused.
+func (s *Server) SetPortForward(fn PortFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ports = fn
+}
+
+func (s *Server) portForwardRoutes() {
+	s.streams.Handle("GET /v2/apps/{app}/pods/{pod}/portforward/{$}", websocket.Handler(func(conn *websocket.Conn) {
+		defer conn.Close()
+		r := conn.Request()
+		appID, podID := r.PathValue("app"), r.PathValue("pod")
+		req := api.PodPortForwardRequest{}
+		if err := websocket.JSON.Receive(conn, &req); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		fn := s.ports
+		found := s.hasPod(appID, podID)
+		s.mu.Unlock()
+		var target net.Conn
+		var err error
+		switch {
+		case !found:
+			err = fmt.Errorf("pods %q not found", podID)
+		case fn == nil:
+			err = fmt.Errorf("error forwarding port %d to pod %s: connection refused", req.Port, podID)
+		default:
+			target, err = fn(appID, podID, req.Port)
+		}
+		if err != nil {
+			websocket.Message.Send(conn, append([]byte{portError}, err.Error()...))
+			return
+		}
+		defer target.Close()
+
+		// Either side closing the connection closes the other.
+		go func() {
+			defer target.Close()
+			for {
+				var msg []byte
+				if err := websocket.Message.Receive(conn, &msg); err != nil {
+					return
+				}
+				if len(msg) > 0 && msg[0] == portData {
+					if _, err := target.Write(msg[1:]); err != nil {
+						return
+					}
+				}
+			}
+		}()
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := target.Read(buf)
+			if n > 0 {
+				if websocket.Message.Send(conn, append([]byte{portData}, buf[:n]...)) != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}))
+}
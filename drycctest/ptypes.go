@@ -0,0 +1,212 @@
+package drycctest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+func (s *Server) ptypeRoutes() {
+	s.handle("GET /v2/apps/{app}/ptypes/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.ptypes)
+	})
+	s.handle("GET /v2/apps/{app}/ptypes/{name}/describe/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		ptype := app.ptype(strings.TrimPrefix(r.PathValue("name"), app.app.ID+"-"))
+		if ptype == nil {
+			writeDetail(w, http.StatusNotFound, "No Ptype matches the given query.")
+			return
+		}
+		image := ""
+		if build := app.latestBuild(); build != nil {
+			image = build.Image
+		}
+		writeList(w, r, api.PtypeStates{{Container: ptype.Name, Image: image}})
+	})
+	s.handle("POST /v2/apps/{app}/ptypes/scale/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		targets := map[string]int{}
+		if !readJSON(w, r, &targets) {
+			return
+		}
+		for name, replicas := range targets {
+			if app.ptype(name) == nil {
+				writeDetail(w, http.StatusBadRequest, fmt.Sprintf("Container type %s does not exist in application", name))
+				return
+			}
+			if replicas < 0 {
+				writeDetail(w, http.StatusBadRequest, "Must be greater than or equal to zero")
+				return
+			}
+		}
+		for name, replicas := range targets {
+			app.scale(name, replicas)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	for _, action := range []string{"restart", "clean"} {
+		s.handle("POST /v2/apps/{app}/ptypes/"+action+"/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+			targets := map[string]string{}
+			if !readJSON(w, r, &targets) {
+				return
+			}
+			if action == "clean" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			for _, ptype := range app.ptypes {
+				if names := targets["ptypes"]; names == "" || slices.Contains(strings.Split(names, ","), ptype.Name) {
+					app.scale(ptype.Name, ptype.AvailableReplicas)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	// pods
+	s.handle("GET /v2/apps/{app}/pods/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		pods := app.pods
+		if ptype := r.URL.Query().Get("ptype"); ptype != "" {
+			pods = slices.DeleteFunc(slices.Clone(pods), func(p api.Pods) bool { return p.Type != ptype })
+		}
+		writeList(w, r, pods)
+	})
+	s.handle("GET /v2/apps/{app}/pods/{pod}/describe/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		i := slices.IndexFunc(app.pods, func(p api.Pods) bool { return p.Name == r.PathValue("pod") })
+		if i < 0 {
+			writeDetail(w, http.StatusNotFound, "No Pod matches the given query.")
+			return
+		}
+		pod := app.pods[i]
+		image := ""
+		if build := app.latestBuild(); build != nil {
+			image = build.Image
+		}
+		state := map[string]map[string]any{"running": {"startedAt": pod.Started}}
+		if pod.Ready != "1/1" && pod.Restarts > 0 {
+			// A pod that restarted without becoming ready is crash looping.
+			state = map[string]map[string]any{"waiting": {"reason": "CrashLoopBackOff"}}
+		}
+		writeList(w, r, api.PodState{{
+			Container:    pod.Type,
+			Image:        image,
+			State:        state,
+			Ready:        pod.Ready == "1/1",
+			RestartCount: pod.Restarts,
+		}})
+	})
+	s.handle("DELETE /v2/apps/{app}/pods/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.PodIDs{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		for id := range strings.SplitSeq(req.PodIDs, ",") {
+			i := slices.IndexFunc(app.pods, func(p api.Pods) bool { return p.Name == id })
+			if i < 0 {
+				writeDetail(w, http.StatusNotFound, fmt.Sprintf("Pod %s does not exist", id))
+				return
+			}
+			// Like a deployment would, replace the deleted pod.
+			app.pods[i] = app.newPod(app.pods[i].Type)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (a *appState) ptype(name string) *api.Ptype {
+	i := slices.IndexFunc(a.ptypes, func(p api.Ptype) bool { return p.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &a.ptypes[i]
+}
+
+// scale sets the replicas of a process type, replacing its pods with ready ones of
+// the latest release.
+func (a *appState) scale(name string, replicas int) {
+	ptype := a.ptype(name)
+	ptype.Release = fmt.Sprintf("v%d", len(a.releases))
+	ptype.Ready = fmt.Sprintf("%d/%d", replicas, replicas)
+	ptype.UpToDate = replicas
+	ptype.AvailableReplicas = replicas
+	ptype.Started = now()
+
+	a.pods = slices.DeleteFunc(a.pods, func(p api.Pods) bool { return p.Type == name })
+	for range replicas {
+		a.pods = append(a.pods, a.newPod(name))
+	}
+}
+
+// rollout restarts every process type on the latest release.
+func (a *appState) rollout() {
+	for _, ptype := range a.ptypes {
+		a.scale(ptype.Name, ptype.AvailableReplicas)
+	}
+}
+
+func (a *appState) newPod(ptype string) api.Pods {
+	return api.Pods{
+		Release: fmt.Sprintf("v%d", len(a.releases)),
+		Type:    ptype,
+		Name:    fmt.Sprintf("%s-%s-%s", a.app.ID, ptype, randomSuffix()),
+		State:   "up",
+		Ready:   "1/1",
+		Started: now(),
+	}
+}
+
+// hasPod returns whether an app has a pod. It is called with mu held.
+func (s *Server) hasPod(appID, podID string) bool {
+	app := s.app(appID)
+	return app != nil && slices.ContainsFunc(app.pods, func(p api.Pods) bool { return p.Name == podID })
+}
+
+// Ptypes returns the process types of an app.
+func (s *Server) Ptypes(appID string) (api.Ptypes, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(app.ptypes), nil
+}
+
+// SetPtypes replaces the process types of an app, so that tests can simulate
+// deployments that aren't ready yet.
+func (s *Server) SetPtypes(appID string, ptypes api.Ptypes) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return err
+	}
+	app.ptypes = slices.Clone(ptypes)
+	return nil
+}
+
+// Pods returns the pods of an app.
+func (s *Server) Pods(appID string) (api.PodsList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(app.pods), nil
+}
+
+// SetPods replaces the pods of an app, so that tests can simulate failing or
+// restarting pods.
+func (s *Server) SetPods(appID string, pods api.PodsList) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	app, err := s.lookup(appID)
+	if err != nil {
+		return err
+	}
+	app.pods = slices.Clone(pods)
+	s.broadcast()
+	return nil
+}
@@ -0,0 +1,245 @@
+package drycctest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+func (s *Server) storageRoutes() {
+	// volumes
+	s.handle("GET /v2/apps/{app}/volumes/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.volumes)
+	})
+	s.handle("POST /v2/apps/{app}/volumes/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.Volume{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Name == "" || req.Size == "" {
+			writeFieldError(w, "name", "This field may not be blank.")
+			return
+		}
+		if app.volume(req.Name) != nil {
+			writeFieldError(w, "name", "volume with this name already exists.")
+			return
+		}
+		ts := now()
+		volume := api.Volume{
+			App: app.app.ID, Name: req.Name, Size: req.Size, Type: req.Type, Parameters: req.Parameters,
+			Created: ts, Updated: ts, UUID: newUUID(),
+		}
+		if volume.Type == "" {
+			volume.Type = "csi"
+		}
+		app.volumes = append(app.volumes, volume)
+		writeJSON(w, http.StatusCreated, volume)
+	})
+	s.handle("GET /v2/apps/{app}/volumes/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		volume := app.volume(r.PathValue("name"))
+		if volume == nil {
+			writeDetail(w, http.StatusNotFound, "No Volume matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, volume)
+	})
+	s.handle("PATCH /v2/apps/{app}/volumes/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		volume := app.volume(r.PathValue("name"))
+		if volume == nil {
+			writeDetail(w, http.StatusNotFound, "No Volume matches the given query.")
+			return
+		}
+		req := api.Volume{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Size != "" {
+			volume.Size = req.Size
+			volume.Updated = now()
+		}
+		writeJSON(w, http.StatusOK, volume)
+	})
+	s.handle("DELETE /v2/apps/{app}/volumes/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		volume := app.volume(r.PathValue("name"))
+		if volume == nil {
+			writeDetail(w, http.StatusNotFound, "No Volume matches the given query.")
+			return
+		}
+		if len(volume.Path) > 0 {
+			writeDetail(w, http.StatusBadRequest, "the volume is not unmounted")
+			return
+		}
+		remove(&app.volumes, func(v api.Volume) bool { return v.Name == volume.Name })
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("PATCH /v2/apps/{app}/volumes/{name}/path/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		volume := app.volume(r.PathValue("name"))
+		if volume == nil {
+			writeDetail(w, http.StatusNotFound, "No Volume matches the given query.")
+			return
+		}
+		req := api.Volume{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		for ptype, path := range req.Path {
+			if path == nil {
+				if _, ok := volume.Path[ptype]; !ok {
+					writeDetail(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s does not exist under path", ptype))
+					return
+				}
+				delete(volume.Path, ptype)
+				continue
+			}
+			if volume.Path == nil {
+				volume.Path = map[string]any{}
+			}
+			volume.Path[ptype] = path
+		}
+		volume.Updated = now()
+		app.newRelease(fmt.Sprintf("%s changed volumes", DefaultUsername))
+		writeJSON(w, http.StatusOK, volume)
+	})
+
+	// resources
+	s.handle("GET /v2/resources/services/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		services := api.ResourceServices{}
+		for _, service := range s.services {
+			services = append(services, service.service)
+		}
+		writeList(w, r, services)
+	})
+	s.handle("GET /v2/resources/services/{service}/plans/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		i := slices.IndexFunc(s.services, func(ss serviceState) bool { return ss.service.Name == r.PathValue("service") })
+		if i < 0 {
+			writeDetail(w, http.StatusNotFound, "No Service matches the given query.")
+			return
+		}
+		writeList(w, r, s.services[i].plans)
+	})
+	s.handle("GET /v2/apps/{app}/resources/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		writeList(w, r, app.resources)
+	})
+	s.handle("POST /v2/apps/{app}/resources/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		req := api.Resource{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if !s.validPlan(req.Plan) {
+			writeFieldError(w, "plan", fmt.Sprintf("plan %s does not exist", req.Plan))
+			return
+		}
+		if app.resource(req.Name) != nil {
+			writeFieldError(w, "name", "resource with this name already exists.")
+			return
+		}
+		ts := now()
+		resource := api.Resource{
+			App: app.app.ID, Name: req.Name, Plan: req.Plan, Options: req.Options, Status: "Ready",
+			Created: ts, Updated: ts, UUID: newUUID(),
+		}
+		app.resources = append(app.resources, resource)
+		writeJSON(w, http.StatusCreated, resource)
+	})
+	s.handle("GET /v2/apps/{app}/resources/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		resource := app.resource(r.PathValue("name"))
+		if resource == nil {
+			writeDetail(w, http.StatusNotFound, "No Resource matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, resource)
+	})
+	s.handle("PUT /v2/apps/{app}/resources/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		resource := app.resource(r.PathValue("name"))
+		if resource == nil {
+			writeDetail(w, http.StatusNotFound, "No Resource matches the given query.")
+			return
+		}
+		req := api.Resource{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Plan != "" {
+			if !s.validPlan(req.Plan) {
+				writeFieldError(w, "plan", fmt.Sprintf("plan %s does not exist", req.Plan))
+				return
+			}
+			resource.Plan = req.Plan
+		}
+		if req.Options != nil {
+			resource.Options = req.Options
+		}
+		resource.Updated = now()
+		writeJSON(w, http.StatusOK, resource)
+	})
+	s.handle("DELETE /v2/apps/{app}/resources/{name}/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		resource := app.resource(r.PathValue("name"))
+		if resource == nil {
+			writeDetail(w, http.StatusNotFound, "No Resource matches the given query.")
+			return
+		}
+		if resource.Binding == "Ready" {
+			writeDetail(w, http.StatusBadRequest, "the plan is still binding")
+			return
+		}
+		remove(&app.resources, func(res api.Resource) bool { return res.Name == resource.Name })
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("PATCH /v2/apps/{app}/resources/{name}/binding/{$}", func(w http.ResponseWriter, r *http.Request, app *appState) {
+		resource := app.resource(r.PathValue("name"))
+		if resource == nil {
+			writeDetail(w, http.StatusNotFound, "No Resource matches the given query.")
+			return
+		}
+		req := api.ResourceBinding{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		switch req.BindAction {
+		case "bind":
+			resource.Binding = "Ready"
+			resource.Data = map[string]any{
+				"username": resource.Name,
+				"password": randomSuffix(),
+				"host":     fmt.Sprintf("%s.%s.svc", resource.Name, app.app.ID),
+			}
+		case "unbind":
+			resource.Binding = ""
+			resource.Data = nil
+		default:
+			writeFieldError(w, "bind_action", "bind_action must be bind or unbind")
+			return
+		}
+		resource.Updated = now()
+		writeJSON(w, http.StatusOK, resource)
+	})
+}
+
+func (s *Server) validPlan(plan string) bool {
+	for _, service := range s.services {
+		for _, p := range service.plans {
+			if service.service.Name+":"+p.Name == plan {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *appState) volume(name string) *api.Volume {
+	i := slices.IndexFunc(a.volumes, func(v api.Volume) bool { return v.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &a.volumes[i]
+}
+
+func (a *appState) resource(name string) *api.Resource {
+	i := slices.IndexFunc(a.resources, func(r api.Resource) bool { return r.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &a.resources[i]
+}
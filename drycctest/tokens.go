@@ -0,0 +1,85 @@
+package drycctest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// tokenState is a token of the user along with its key.
+type tokenState struct {
+	token api.Token
+	key   string
+}
+
+func newToken(key, alias string) tokenState {
+	ts := now()
+	return tokenState{
+		token: api.Token{
+			UUID:    newUUID(),
+			Owner:   DefaultUsername,
+			Alias:   alias,
+			Key:     key[:min(len(key), 6)] + "...",
+			Created: ts,
+			Updated: ts,
+		},
+		key: key,
+	}
+}
+
+func newKey() string {
+	return strings.ReplaceAll(newUUID(), "-", "")
+}
+
+// authenticate reports whether r carries a valid token, marking the token as used.
+func (s *Server) authenticate(r *http.Request) bool {
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "token ")
+	if !ok {
+		return false
+	}
+	for i := range s.tokens {
+		if s.tokens[i].key == key {
+			s.tokens[i].token.Updated = now()
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) tokenRoutes() {
+	s.handle("GET /v2/tokens/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		tokens := []api.Token{}
+		for _, token := range s.tokens {
+			tokens = append(tokens, token.token)
+		}
+		writeList(w, r, tokens)
+	})
+	s.handle("POST /v2/tokens/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		req := api.TokenCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.createToken(req.Alias))
+	})
+	s.handle("DELETE /v2/tokens/{uuid}/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		if !remove(&s.tokens, func(t tokenState) bool { return t.token.UUID == r.PathValue("uuid") }) {
+			writeDetail(w, http.StatusNotFound, "No Token matches the given query.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// createToken adds a token with the given alias.
+func (s *Server) createToken(alias string) api.TokenCreateResponse {
+	token := newToken(newKey(), alias)
+	s.tokens = append(s.tokens, token)
+	return api.TokenCreateResponse{
+		UUID:    token.token.UUID,
+		Owner:   token.token.Owner,
+		Alias:   token.token.Alias,
+		Token:   token.key,
+		Created: token.token.Created,
+	}
+}
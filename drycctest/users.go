@@ -0,0 +1,79 @@
+package drycctest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+func newUser(id int, username string) api.User {
+	ts := now()
+	return api.User{
+		ID:         id,
+		Username:   username,
+		Email:      username + "@example.com",
+		IsActive:   true,
+		LastLogin:  ts,
+		DateJoined: ts,
+	}
+}
+
+func (s *Server) userRoutes() {
+	s.handle("GET /v2/users/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		writeList(w, r, s.users)
+	})
+	s.handle("GET /v2/users/{username}/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		user := s.user(r.PathValue("username"))
+		if user == nil {
+			writeDetail(w, http.StatusNotFound, "No User matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	})
+	s.handle("PATCH /v2/users/{username}/enable/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		s.updateUser(w, r.PathValue("username"), func(u *api.User) { u.IsActive = true })
+	})
+	s.handle("PATCH /v2/users/{username}/disable/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		s.updateUser(w, r.PathValue("username"), func(u *api.User) { u.IsActive = false })
+	})
+	s.handle("POST /v2/admin/perms/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		req := api.AdminPermRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		s.updateUser(w, req.Username, func(u *api.User) { u.IsSuperuser = true })
+	})
+	s.handle("DELETE /v2/admin/perms/{username}/{$}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		s.updateUser(w, r.PathValue("username"), func(u *api.User) { u.IsSuperuser = false })
+	})
+}
+
+// updateUser changes a user and answers without content, as the admin endpoints of the
+// controller do.
+func (s *Server) updateUser(w http.ResponseWriter, username string, update func(*api.User)) {
+	user := s.user(username)
+	if user == nil {
+		writeDetail(w, http.StatusNotFound, "No User matches the given query.")
+		return
+	}
+	update(user)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddUser adds a user that isn't a superuser nor staff to the controller.
+func (s *Server) AddUser(username string) api.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := newUser(len(s.users)+1, username)
+	s.users = append(s.users, user)
+	return user
+}
+
+func (s *Server) user(username string) *api.User {
+	i := slices.IndexFunc(s.users, func(u api.User) bool { return u.Username == username })
+	if i < 0 {
+		return nil
+	}
+	return &s.users[i]
+}
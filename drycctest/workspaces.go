@@ -0,0 +1,73 @@
+package drycctest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+func (s *Server) workspaceRoutes() {
+	s.handle("GET /v2/workspaces", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		writeList(w, r, s.workspaces)
+	})
+	s.handle("POST /v2/workspaces", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		req := api.WorkspaceCreateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Name == "" {
+			writeFieldError(w, "name", "This field may not be blank.")
+			return
+		}
+		if s.workspace(req.Name) != nil {
+			writeFieldError(w, "name", "workspace with this name already exists.")
+			return
+		}
+		ts := now()
+		workspace := api.Workspace{
+			ID: len(s.workspaces) + 1, Name: req.Name, Email: req.Email, Created: ts, Updated: ts,
+		}
+		s.workspaces = append(s.workspaces, workspace)
+		writeJSON(w, http.StatusCreated, workspace)
+	})
+	s.handle("GET /v2/workspaces/{name}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		workspace := s.workspace(r.PathValue("name"))
+		if workspace == nil {
+			writeDetail(w, http.StatusNotFound, "No Workspace matches the given query.")
+			return
+		}
+		writeJSON(w, http.StatusOK, workspace)
+	})
+	s.handle("PATCH /v2/workspaces/{name}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		workspace := s.workspace(r.PathValue("name"))
+		if workspace == nil {
+			writeDetail(w, http.StatusNotFound, "No Workspace matches the given query.")
+			return
+		}
+		req := api.WorkspaceUpdateRequest{}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Email != "" {
+			workspace.Email = req.Email
+			workspace.Updated = now()
+		}
+		writeJSON(w, http.StatusOK, workspace)
+	})
+	s.handle("DELETE /v2/workspaces/{name}", func(w http.ResponseWriter, r *http.Request, _ *appState) {
+		if !remove(&s.workspaces, func(ws api.Workspace) bool { return ws.Name == r.PathValue("name") }) {
+			writeDetail(w, http.StatusNotFound, "No Workspace matches the given query.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) workspace(name string) *api.Workspace {
+	i := slices.IndexFunc(s.workspaces, func(ws api.Workspace) bool { return ws.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return &s.workspaces[i]
+}
@@ -101,6 +101,44 @@ func (e ErrNotFound) Error() string {
 	return e.errorMsg
 }
 
+// APIError is returned when the controller responds with an error status. It preserves the
+// details of the response, while the SDK error it was matched to, such as ErrInvalidAppName
+// or ErrNotFound, remains reachable through [errors.Is] and [errors.As]:
+//
+//	if errors.Is(err, drycc.ErrDuplicateApp) {
+//	    ...
+//	}
+//	var apiErr *drycc.APIError
+//	if errors.As(err, &apiErr) {
+//	    fmt.Println(apiErr.StatusCode, apiErr.FieldErrors)
+//	}
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method is the HTTP method of the request, if known.
+	Method string
+	// URL is the URL of the request, if known.
+	URL string
+	// Body is the raw body of the response.
+	Body []byte
+	// FieldErrors holds the per-field validation messages the controller sends on 400.
+	FieldErrors map[string][]string
+	// Detail is the detail message of the response, if any.
+	Detail string
+	// Err is the SDK error matching the response. Responses the SDK doesn't recognize
+	// are reported as an unknown error carrying the status code and body.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the SDK error matching the response.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
 // checkForErrors tries to match up an API error with an predefined error in the SDK.
 func checkForErrors(res *http.Response) error {
 	if res.StatusCode >= 200 && res.StatusCode < 400 {
@@ -108,36 +146,70 @@ func checkForErrors(res *http.Response) error {
 	}
 	defer res.Body.Close()
 
+	apiErr := &APIError{StatusCode: res.StatusCode}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.String()
+	}
+
 	// If the response is HTML (e.g. from a reverse proxy error page), avoid exposing raw HTML.
 	if ct := res.Header.Get("Content-Type"); strings.Contains(ct, "text/html") {
-		switch res.StatusCode {
-		case 401:
-			return ErrUnauthorized
-		case 403:
-			return ErrForbidden
-		case 404:
-			return ErrNotFound{"Not Found"}
-		case 405:
-			return ErrMethodNotAllowed
-		case 409:
-			return ErrConflict
-		case 500:
-			return ErrServerError
-		default:
-			return fmt.Errorf(formatErrUnknown, res.StatusCode, http.StatusText(res.StatusCode))
-		}
+		apiErr.Err = htmlError(res.StatusCode)
+		return apiErr
 	}
 
 	out, err := io.ReadAll(res.Body)
 	if err != nil {
-		return unknownServerError(res.StatusCode, err.Error())
+		apiErr.Err = unknownServerError(res.StatusCode, err.Error())
+		return apiErr
+	}
+	apiErr.Body = out
+
+	bodyMap := make(map[string]any)
+	if err := json.Unmarshal(out, &bodyMap); err == nil {
+		apiErr.Detail, _ = bodyMap["detail"].(string)
+		for field := range bodyMap {
+			if _, ok := bodyMap[field].([]any); !ok {
+				continue
+			}
+			if apiErr.FieldErrors == nil {
+				apiErr.FieldErrors = make(map[string][]string)
+			}
+			apiErr.FieldErrors[field] = arrayContents(bodyMap, field)
+		}
 	}
 
-	switch res.StatusCode {
+	apiErr.Err = matchError(res.StatusCode, out)
+	return apiErr
+}
+
+// htmlError matches the status of an HTML error page with an error in the SDK.
+func htmlError(statusCode int) error {
+	switch statusCode {
+	case 401:
+		return ErrUnauthorized
+	case 403:
+		return ErrForbidden
+	case 404:
+		return ErrNotFound{"Not Found"}
+	case 405:
+		return ErrMethodNotAllowed
+	case 409:
+		return ErrConflict
+	case 500:
+		return ErrServerError
+	default:
+		return fmt.Errorf(formatErrUnknown, statusCode, http.StatusText(statusCode))
+	}
+}
+
+// matchError matches the status and body of a response with an error in the SDK.
+func matchError(statusCode int, out []byte) error {
+	switch statusCode {
 	case 400:
 		bodyMap := make(map[string]any)
 		if err := json.Unmarshal(out, &bodyMap); err != nil {
-			return unknownServerError(res.StatusCode, string(out))
+			return unknownServerError(statusCode, string(out))
 		}
 
 		if scanResponse(bodyMap, "username", []string{fieldReqMsg, invalidUserMsg}, true) {
@@ -215,7 +287,7 @@ func checkForErrors(res *http.Response) error {
 				return ErrTagNotFound
 			}
 		}
-		return unknownServerError(res.StatusCode, string(out))
+		return unknownServerError(statusCode, string(out))
 	case 401:
 		return ErrUnauthorized
 	case 403:
@@ -232,16 +304,16 @@ func checkForErrors(res *http.Response) error {
 	case 422:
 		bodyMap := make(map[string]any)
 		if err := json.Unmarshal(out, &bodyMap); err != nil {
-			return unknownServerError(res.StatusCode, fmt.Sprintf(jsonParsingError, err, string(out)))
+			return unknownServerError(statusCode, fmt.Sprintf(jsonParsingError, err, string(out)))
 		}
 		if v, ok := bodyMap["detail"].(string); ok {
 			return ErrUnprocessable{v}
 		}
-		return unknownServerError(res.StatusCode, string(out))
+		return unknownServerError(statusCode, string(out))
 	case 500:
 		return ErrServerError
 	default:
-		return unknownServerError(res.StatusCode, string(out))
+		return unknownServerError(statusCode, string(out))
 	}
 }
 
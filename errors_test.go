@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -315,3 +316,71 @@ newline"}`),
 		}
 	}
 }
+
+func TestAPIError(t *testing.T) {
+	req, err := http.NewRequest("POST", "http://drycc.example.com/v2/apps/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{"id":["App name can only contain a-z (lowercase), 0-9 and hyphens"],"workspace":["This field may not be blank."]}`
+	res := &http.Response{
+		StatusCode: 400,
+		Request:    req,
+		Body:       readCloser(body),
+	}
+
+	err = checkForErrors(res)
+	if !errors.Is(err, ErrInvalidAppName) {
+		t.Errorf(failureMessage, ErrInvalidAppName, err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, Got %T", err)
+	}
+	if apiErr.StatusCode != 400 || apiErr.Method != "POST" || apiErr.URL != "http://drycc.example.com/v2/apps/" {
+		t.Errorf("Unexpected request details %d %s %s", apiErr.StatusCode, apiErr.Method, apiErr.URL)
+	}
+	if string(apiErr.Body) != body {
+		t.Errorf("Expected %s, Got %s", body, apiErr.Body)
+	}
+	expected := map[string][]string{
+		"id":        {invalidAppNameMsg},
+		"workspace": {fieldReqMsg},
+	}
+	if !reflect.DeepEqual(expected, apiErr.FieldErrors) {
+		t.Errorf("Expected %v, Got %v", expected, apiErr.FieldErrors)
+	}
+}
+
+func TestAPIErrorDetail(t *testing.T) {
+	res := &http.Response{
+		StatusCode: 404,
+		Body:       readCloser(`{"detail":"No App matches the given query."}`),
+	}
+
+	err := checkForErrors(res)
+	var notFound ErrNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected ErrNotFound, Got %T", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, Got %T", err)
+	}
+	if apiErr.Detail != "No App matches the given query." {
+		t.Errorf("Expected detail, Got %q", apiErr.Detail)
+	}
+	if apiErr.FieldErrors != nil {
+		t.Errorf("Expected no field errors, Got %v", apiErr.FieldErrors)
+	}
+
+	res = &http.Response{
+		StatusCode: 418,
+		Body:       readCloser(`teapot`),
+	}
+	if err := checkForErrors(res); !errors.As(err, &apiErr) || apiErr.StatusCode != 418 {
+		t.Errorf("Expected an *APIError for an unknown status, Got %v", err)
+	}
+}
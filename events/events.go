@@ -2,8 +2,9 @@
 package events
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,32 +12,32 @@ import (
 
 // ListPodEvents lists events of an app process.
 func ListPodEvents(c *drycc.Client, appID string, podName string, results int) (api.AppEvents, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/events/?pod_name=%s", appID, podName)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.AppEvent{}, -1, reqErr
-	}
-
-	var events []api.AppEvent
-	if err := json.Unmarshal([]byte(body), &events); err != nil {
-		return []api.AppEvent{}, -1, err
-	}
-
-	return events, count, reqErr
+	return ListPodEventsWithContext(context.Background(), c, appID, podName, results)
+}
+
+// ListPodEventsWithContext is like [ListPodEvents] but carries ctx.
+func ListPodEventsWithContext(ctx context.Context, c *drycc.Client, appID string, podName string, results int) (api.AppEvents, int, error) {
+	return drycc.List[api.AppEvent](ctx, c, fmt.Sprintf("/v2/apps/%s/events/?pod_name=%s", appID, podName), results)
+}
+
+// AllPodEvents returns an iterator over every event of a pod, following the controller's
+// pagination and fetching pageSize results per request.
+func AllPodEvents(ctx context.Context, c *drycc.Client, appID string, podName string, pageSize int) iter.Seq2[api.AppEvent, error] {
+	return drycc.All[api.AppEvent](ctx, c, fmt.Sprintf("/v2/apps/%s/events/?pod_name=%s", appID, podName), pageSize)
 }
 
 // ListPtypeEvents lists events of an app ptype.
 func ListPtypeEvents(c *drycc.Client, appID string, ptype string, results int) (api.AppEvents, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/events/?ptype=%s-%s", appID, appID, ptype)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.AppEvent{}, -1, reqErr
-	}
-
-	var events []api.AppEvent
-	if err := json.Unmarshal([]byte(body), &events); err != nil {
-		return []api.AppEvent{}, -1, err
-	}
-
-	return events, count, reqErr
+	return ListPtypeEventsWithContext(context.Background(), c, appID, ptype, results)
+}
+
+// ListPtypeEventsWithContext is like [ListPtypeEvents] but carries ctx.
+func ListPtypeEventsWithContext(ctx context.Context, c *drycc.Client, appID string, ptype string, results int) (api.AppEvents, int, error) {
+	return drycc.List[api.AppEvent](ctx, c, fmt.Sprintf("/v2/apps/%s/events/?ptype=%s-%s", appID, appID, ptype), results)
+}
+
+// AllPtypeEvents returns an iterator over every event of a process type, following the controller's
+// pagination and fetching pageSize results per request.
+func AllPtypeEvents(ctx context.Context, c *drycc.Client, appID string, ptype string, pageSize int) iter.Seq2[api.AppEvent, error] {
+	return drycc.All[api.AppEvent](ctx, c, fmt.Sprintf("/v2/apps/%s/events/?ptype=%s-%s", appID, appID, ptype), pageSize)
 }
@@ -2,8 +2,9 @@
 package gateways
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,55 +12,38 @@ import (
 
 // List gateways registered with an app.
 func List(c *drycc.Client, appID string, results int) (api.Gateways, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/gateways/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Gateway{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var gateways []api.Gateway
-	if err := json.Unmarshal([]byte(body), &gateways); err != nil {
-		return []api.Gateway{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Gateways, int, error) {
+	return drycc.List[api.Gateway](ctx, c, fmt.Sprintf("/v2/apps/%s/gateways/", appID), results)
+}
 
-	return gateways, count, reqErr
+// All returns an iterator over every gateway of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Gateway, error] {
+	return drycc.All[api.Gateway](ctx, c, fmt.Sprintf("/v2/apps/%s/gateways/", appID), pageSize)
 }
 
 // New adds a gateway to an app.
 func New(c *drycc.Client, appID string, name string, port int, protocol string) error {
-	u := fmt.Sprintf("/v2/apps/%s/gateways/", appID)
+	return NewWithContext(context.Background(), c, appID, name, port, protocol)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID string, name string, port int, protocol string) error {
 	req := api.GatewayCreateRequest{Name: name, Port: port, Protocol: protocol}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-
-	return reqErr
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/gateways/", appID), req)
 }
 
 // Delete removes a gateway or listener of gateway from an app.
 func Delete(c *drycc.Client, appID string, name string, port int, protocol string) error {
-	u := fmt.Sprintf("/v2/apps/%s/gateways/", appID)
+	return DeleteWithContext(context.Background(), c, appID, name, port, protocol)
+}
 
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, name string, port int, protocol string) error {
 	req := api.GatewayRemoveRequest{Name: name, Port: port, Protocol: protocol}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, err := c.Request("DELETE", u, body)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/gateways/", appID), req)
 }
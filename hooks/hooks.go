@@ -4,7 +4,7 @@
 package hooks
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -13,41 +13,23 @@ import (
 
 // UserFromKey retrives a user from their SSH key fingerprint.
 func UserFromKey(c *drycc.Client, fingerprint string) (api.UserApps, error) {
-	res, reqErr := c.Request("GET", fmt.Sprintf("/v2/hooks/key/%s", fingerprint), nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.UserApps{}, reqErr
-	}
-
-	defer res.Body.Close()
-
-	resUser := api.UserApps{}
-	if err := json.NewDecoder(res.Body).Decode(&resUser); err != nil {
-		return api.UserApps{}, err
-	}
+	return UserFromKeyWithContext(context.Background(), c, fingerprint)
+}
 
-	return resUser, reqErr
+// UserFromKeyWithContext is like [UserFromKey] but carries ctx.
+func UserFromKeyWithContext(ctx context.Context, c *drycc.Client, fingerprint string) (api.UserApps, error) {
+	return drycc.Get[api.UserApps](ctx, c, fmt.Sprintf("/v2/hooks/key/%s", fingerprint))
 }
 
 // GetAppConfig retrives an app's configuration from the controller.
 func GetAppConfig(c *drycc.Client, username, app string) (api.Config, error) {
-	req := api.ConfigHookRequest{User: username, App: app}
-	b, err := json.Marshal(req)
-	if err != nil {
-		return api.Config{}, err
-	}
-
-	res, reqErr := c.Request("POST", "/v2/hooks/config/", b)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Config{}, reqErr
-	}
-	defer res.Body.Close()
-
-	config := api.Config{}
-	if err := json.NewDecoder(res.Body).Decode(&config); err != nil {
-		return api.Config{}, err
-	}
+	return GetAppConfigWithContext(context.Background(), c, username, app)
+}
 
-	return config, reqErr
+// GetAppConfigWithContext is like [GetAppConfig] but carries ctx.
+func GetAppConfigWithContext(ctx context.Context, c *drycc.Client, username, app string) (api.Config, error) {
+	req := api.ConfigHookRequest{User: username, App: app}
+	return drycc.Post[api.Config](ctx, c, "/v2/hooks/config/", req)
 }
 
 // CreateBuild creates a new release of an application. It returns the version of the new release.
@@ -55,6 +37,13 @@ func GetAppConfig(c *drycc.Client, username, app string) (api.Config, error) {
 // location for the dockerfile app the absolute url to the tar file for a buldpack app.
 func CreateBuild(c *drycc.Client, username, app, image, stack, gitSha string,
 	procfile api.ProcessType, dryccfile map[string]any, dockerfile string,
+) (int, error) {
+	return CreateBuildWithContext(context.Background(), c, username, app, image, stack, gitSha, procfile, dryccfile, dockerfile)
+}
+
+// CreateBuildWithContext is like [CreateBuild] but carries ctx.
+func CreateBuildWithContext(ctx context.Context, c *drycc.Client, username, app, image, stack, gitSha string,
+	procfile api.ProcessType, dryccfile map[string]any, dockerfile string,
 ) (int, error) {
 	req := api.BuildHookRequest{
 		Sha:        gitSha,
@@ -67,21 +56,9 @@ func CreateBuild(c *drycc.Client, username, app, image, stack, gitSha string,
 		Dryccfile:  dryccfile,
 	}
 
-	b, err := json.Marshal(req)
+	res, err := drycc.Post[map[string]map[string]int](ctx, c, "/v2/hooks/build/", req)
 	if err != nil {
 		return -1, err
 	}
-
-	res, reqErr := c.Request("POST", "/v2/hooks/build/", b)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return -1, reqErr
-	}
-	defer res.Body.Close()
-
-	resMap := make(map[string]map[string]int)
-	if err := json.NewDecoder(res.Body).Decode(&resMap); err != nil {
-		return -1, err
-	}
-
-	return resMap["release"]["version"], reqErr
+	return res["release"]["version"], nil
 }
@@ -2,31 +2,80 @@ package drycc
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
 )
 
 // createHTTPClient creates a HTTP Client with proper SSL options.
-func createHTTPClient(sslVerify bool) *http.Client {
-	tr := &http.Transport{
-		TLSClientConfig:   &tls.Config{InsecureSkipVerify: !sslVerify},
-		DisableKeepAlives: true,
-		Proxy:             http.ProxyFromEnvironment,
+func createHTTPClient(tlsConfig *tls.Config, opts options) *http.Client {
+	client := &http.Client{Timeout: opts.timeout}
+	if opts.transport != nil {
+		client.Transport = opts.transport
+		return client
 	}
-	return &http.Client{Transport: tr}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsConfig
+	tr.DisableKeepAlives = !opts.keepAlive
+	tr.MaxConnsPerHost = opts.maxConnsPerHost
+	tr.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if opts.maxConnsPerHost > 0 {
+		tr.MaxIdleConnsPerHost = min(opts.maxConnsPerHost, DefaultMaxIdleConnsPerHost)
+	}
+	tr.ForceAttemptHTTP2 = opts.http2
+	if !opts.http2 {
+		// A non-nil empty map disables HTTP/2.
+		tr.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	client.Transport = tr
+	return client
+}
+
+type noRedirectsKey struct{}
+
+// WithoutRedirects returns a copy of ctx that makes requests carrying it return redirect
+// responses to the caller instead of following them.
+func WithoutRedirects(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRedirectsKey{}, true)
+}
+
+type tokenKey struct{}
+
+// WithToken returns a copy of ctx that makes requests carrying it authenticate with token
+// instead of the token of the client.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// httpDo sends req with the HTTP client. The shared HTTP client is left untouched when
+// redirects are disabled for the request, so that concurrent requests aren't affected.
+func (c *Client) httpDo(req *http.Request) (*http.Response, error) {
+	if noRedirects, _ := req.Context().Value(noRedirectsKey{}).(bool); noRedirects {
+		hc := *c.HTTPClient
+		hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return hc.Do(req)
+	}
+	return c.HTTPClient.Do(req)
 }
 
 // Do sends an HTTP request and returns an HTTP response,
 // following policy (such as redirects, cookies, auth) as configured on the client.
+// Warnings raised by the response are recorded, see [Client.Warnings].
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	if c.Token != "" {
-		req.Header.Add("Authorization", "token "+c.Token)
+	token, explicit := req.Context().Value(tokenKey{}).(string)
+	if !explicit {
+		token = c.CurrentToken()
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
 	}
 
 	if c.ServiceKey != "" {
@@ -38,27 +87,58 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 
 	addUserAgent(&req.Header, c.UserAgent)
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.send(req)
 	if err != nil {
 		return res, err
 	}
 
-	if err = checkForErrors(res); err != nil {
+	err = checkForErrors(res)
+	if errors.Is(err, ErrUnauthorized) && !explicit && c.TokenSource != nil && canReplay(req) {
+		res, err = c.replay(req, token, err)
+		if err != nil {
+			return res, err
+		}
+		err = checkForErrors(res)
+	}
+	if err != nil {
 		return res, err
 	}
 
-	apiVersion := res.Header.Get("DRYCC_API_VERSION")
-
 	// Update controller api and platform version
-	c.ControllerAPIVersion = apiVersion
-	setControllerVersion(c, res.Header)
+	c.setVersions(res.Header)
 
-	// Return results along with api compatibility error
-	return res, CheckAPICompatibility(apiVersion, APIVersion)
+	if err = c.checkWarnings(res); err != nil {
+		res.Body.Close()
+		return res, err
+	}
+	return res, nil
+}
+
+// replay sends req again with a token refreshed from stale, the token it was rejected with.
+// If the token can't be refreshed, the error of the rejection is returned along with the
+// error of the refresh.
+func (c *Client) replay(req *http.Request, stale string, rejected error) (*http.Response, error) {
+	token, err := c.refreshToken(req.Context(), stale)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %w", rejected, err)
+	}
+	if req.GetBody != nil {
+		if req.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	req.Header.Set("Authorization", "token "+token)
+	return c.send(req)
 }
 
 // NewRequest wraps [NewRequestWithContext] using [context.Background].
 func (c *Client) NewRequest(method string, path string, body io.Reader) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// NewRequestWithContext creates a request for the given method and path relative to the
+// controller URL. The path may include a query string.
+func (c *Client) NewRequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
 	url := *c.ControllerURL
 
 	if strings.Contains(path, "?") {
@@ -68,14 +148,20 @@ func (c *Client) NewRequest(method string, path string, body io.Reader) (*http.R
 	} else {
 		url.Path = path
 	}
-	return http.NewRequest(method, url.String(), body)
+	return http.NewRequestWithContext(ctx, method, url.String(), body)
 }
 
 // Request makes a HTTP request with the given method, relative URL, and body on the controller.
 // It also sets the Authorization and Content-Type headers to properly authenticate and communicate
 // API. This is primarily intended to use be used by the SDK itself, but could potentially be used elsewhere.
 func (c *Client) Request(method string, path string, body []byte) (*http.Response, error) {
-	req, err := c.NewRequest(method, path, bytes.NewBuffer(body))
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+// RequestWithContext is like [Client.Request] but carries ctx, so the request is
+// aborted when ctx is canceled or its deadline expires.
+func (c *Client) RequestWithContext(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, method, path, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -84,59 +170,43 @@ func (c *Client) Request(method string, path string, body []byte) (*http.Respons
 
 // LimitedRequest allows limiting the number of responses in a request.
 func (c *Client) LimitedRequest(path string, results int) (string, int, error) {
-	var query string
-	u, err := url.Parse(path)
-	if err != nil {
-		return "", -1, err
-	}
-
-	if len(u.Query()) > 0 {
-		query = "&limit=" + strconv.Itoa(results)
-	} else {
-		query = "?limit=" + strconv.Itoa(results)
-	}
-
-	res, reqErr := c.Request("GET", path+query, nil)
-
-	if reqErr != nil && !IsErrAPIMismatch(reqErr) {
-		return "", -1, reqErr
-	}
-
-	defer res.Body.Close()
+	return c.LimitedRequestWithContext(context.Background(), path, results)
+}
 
-	body, err := io.ReadAll(res.Body)
+// LimitedRequestWithContext is like [Client.LimitedRequest] but carries ctx.
+func (c *Client) LimitedRequestWithContext(ctx context.Context, path string, results int) (string, int, error) {
+	page, err := c.PageWithContext(ctx, path, results)
 	if err != nil {
 		return "", -1, err
 	}
 
-	r := make(map[string]any)
-	if err = json.Unmarshal([]byte(body), &r); err != nil {
+	out := bytes.Buffer{}
+	if err := json.Compact(&out, page.Results); err != nil {
 		return "", -1, err
 	}
 
-	out, err := json.Marshal(r["results"].([]any))
-	if err != nil {
-		return "", -1, err
-	}
-
-	return string(out), int(r["count"].(float64)), reqErr
+	return out.String(), page.Count, nil
 }
 
 // CheckConnection checks that the user is connected to a network and the URL points to a valid controller.
 func (c *Client) CheckConnection() error {
+	return c.CheckConnectionWithContext(context.Background())
+}
+
+// CheckConnectionWithContext is like [Client.CheckConnection] but carries ctx.
+func (c *Client) CheckConnectionWithContext(ctx context.Context) error {
 	errorMessage := `%s does not appear to be a valid Drycc controller.
 Make sure that the Controller URI is correct, the server is running and
 your drycc version is correct.`
 
 	// Make a request to /v2/ and expect a 401 response
-	req, err := http.NewRequest("GET", c.ControllerURL.String()+"/v2/", bytes.NewBuffer(nil))
-	addUserAgent(&req.Header, c.UserAgent)
-
+	req, err := http.NewRequestWithContext(ctx, "GET", c.ControllerURL.String()+"/v2/", bytes.NewBuffer(nil))
 	if err != nil {
 		return err
 	}
+	addUserAgent(&req.Header, c.UserAgent)
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.doer().Do(req)
 	if err != nil {
 		return err
 	}
@@ -147,29 +217,31 @@ your drycc version is correct.`
 	}
 
 	// Update controller api version
-	apiVersion := res.Header.Get("DRYCC_API_VERSION")
-	c.ControllerAPIVersion = apiVersion
-	setControllerVersion(c, res.Header)
+	c.setVersions(res.Header)
 
-	return CheckAPICompatibility(apiVersion, APIVersion)
+	return c.checkWarnings(res)
 }
 
 // Healthcheck can be called to see if the controller is healthy
 func (c *Client) Healthcheck() error {
+	return c.HealthcheckWithContext(context.Background())
+}
+
+// HealthcheckWithContext is like [Client.Healthcheck] but carries ctx.
+func (c *Client) HealthcheckWithContext(ctx context.Context) error {
 	// Make a request to /healthz and expect an ok HTTP response
 	controllerURL := c.ControllerURL.String()
 	// Don't double the last slash in the URL path
 	if !strings.HasSuffix(controllerURL, "/") {
 		controllerURL = controllerURL + "/"
 	}
-	req, err := http.NewRequest("GET", controllerURL+"healthz", bytes.NewBuffer(nil))
-	addUserAgent(&req.Header, c.UserAgent)
-
+	req, err := http.NewRequestWithContext(ctx, "GET", controllerURL+"healthz", bytes.NewBuffer(nil))
 	if err != nil {
 		return err
 	}
+	addUserAgent(&req.Header, c.UserAgent)
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.doer().Do(req)
 	if err != nil {
 		return err
 	}
@@ -180,17 +252,18 @@ func (c *Client) Healthcheck() error {
 	res.Body.Close()
 
 	// Update controller api version
-	apiVersion := res.Header.Get("DRYCC_API_VERSION")
-	c.ControllerAPIVersion = apiVersion
-	setControllerVersion(c, res.Header)
+	c.setVersions(res.Header)
 
-	return CheckAPICompatibility(apiVersion, APIVersion)
+	return c.checkWarnings(res)
 }
 
 func addUserAgent(headers *http.Header, userAgent string) {
 	headers.Add("User-Agent", userAgent)
 }
 
-func setControllerVersion(c *Client, headers http.Header) {
+func (c *Client) setVersions(headers http.Header) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	c.ControllerAPIVersion = headers.Get("DRYCC_API_VERSION")
 	c.ControllerVersion = headers.Get("DRYCC_PLATFORM_VERSION")
 }
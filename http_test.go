@@ -1,6 +1,8 @@
 package drycc
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -139,13 +141,24 @@ func TestAPIMistmatch(t *testing.T) {
 	}
 	drycc.UserAgent = "test"
 
-	if err = drycc.CheckConnection(); err != ErrAPIMismatch {
-		t.Error("Expected ErrAPIMismatch error")
+	// The mismatch is recorded as a warning rather than returned.
+	if err = drycc.CheckConnection(); err != nil {
+		t.Error(err)
 	}
 
 	if drycc.ControllerAPIVersion != handler.Version {
 		t.Errorf("Expected %s, Got %s", handler.Version, drycc.ControllerAPIVersion)
 	}
+
+	warnings := drycc.Warnings()
+	if len(warnings) != 1 || warnings[0].Kind != WarningAPIMismatch || !errors.Is(warnings[0].Err, ErrAPIMismatch) {
+		t.Errorf("Expected an ErrAPIMismatch warning, Got %v", warnings)
+	}
+
+	drycc.Strict = true
+	if err = drycc.CheckConnection(); !errors.Is(err, ErrAPIMismatch) {
+		t.Error("Expected ErrAPIMismatch error")
+	}
 }
 
 func TestBasicRequest(t *testing.T) {
@@ -256,3 +269,57 @@ func TestHealthcheck(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRequestWithContext(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = drycc.RequestWithContext(ctx, "POST", "/request/", []byte("test")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, Got %v", context.Canceled, err)
+	}
+
+	if _, _, err = drycc.LimitedRequestWithContext(ctx, "/limited/", 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, Got %v", context.Canceled, err)
+	}
+
+	if err = drycc.HealthcheckWithContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, Got %v", context.Canceled, err)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+	drycc.ServiceKey = "testing"
+
+	res, err := drycc.RequestWithContext(WithToken(context.Background(), "abc"), "POST", "/request/", []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("Expected %d, Got %d", http.StatusOK, res.StatusCode)
+	}
+}
@@ -2,8 +2,9 @@
 package keys
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,52 +12,40 @@ import (
 
 // List lists a user's ssh keys.
 func List(c *drycc.Client, results int) (api.Keys, int, error) {
-	body, count, reqErr := c.LimitedRequest("/v2/keys/", results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Key{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, results)
+}
 
-	var keys []api.Key
-	if err := json.Unmarshal([]byte(body), &keys); err != nil {
-		return []api.Key{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, results int) (api.Keys, int, error) {
+	return drycc.List[api.Key](ctx, c, "/v2/keys/", results)
+}
 
-	return keys, count, reqErr
+// All returns an iterator over every key of the user, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, pageSize int) iter.Seq2[api.Key, error] {
+	return drycc.All[api.Key](ctx, c, "/v2/keys/", pageSize)
 }
 
 // New adds a new ssh key for the user. This is used for authenting with the git
 // remote for the builder. This key must be unique to the current user, or the error
 // drycc.ErrDuplicateKey will be returned.
 func New(c *drycc.Client, id string, pubKey string) (api.Key, error) {
+	return NewWithContext(context.Background(), c, id, pubKey)
+}
+
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, id string, pubKey string) (api.Key, error) {
 	req := api.KeyCreateRequest{ID: id, Public: pubKey}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Key{}, err
-	}
-
-	res, reqErr := c.Request("POST", "/v2/keys/", body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Key{}, reqErr
-	}
-	defer res.Body.Close()
-
-	key := api.Key{}
-	if err = json.NewDecoder(res.Body).Decode(&key); err != nil {
-		return api.Key{}, err
-	}
-
-	return key, reqErr
+	return drycc.Post[api.Key](ctx, c, "/v2/keys/", req)
 }
 
 // Delete removes a user's ssh key. The key ID will be the key comment, usually the email or user@hostname
 // of the user. The exact keyID can be retrieved with List()
 func Delete(c *drycc.Client, keyID string) error {
-	u := fmt.Sprintf("/v2/keys/%s", keyID)
+	return DeleteWithContext(context.Background(), c, keyID)
+}
 
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, keyID string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/keys/%s", keyID), nil)
 }
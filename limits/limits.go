@@ -2,7 +2,7 @@
 package limits
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strings"
 
@@ -12,24 +12,25 @@ import (
 
 // Specs is list all available limit specs
 func Specs(c *drycc.Client, keywords string, results int) ([]api.LimitSpec, int, error) {
+	return SpecsWithContext(context.Background(), c, keywords, results)
+}
+
+// SpecsWithContext is like [Specs] but carries ctx.
+func SpecsWithContext(ctx context.Context, c *drycc.Client, keywords string, results int) ([]api.LimitSpec, int, error) {
 	u := "/v2/limits/specs/"
 	if keywords != "" {
 		u += fmt.Sprintf("?keywords=%s", keywords)
 	}
-
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.LimitSpec{}, -1, reqErr
-	}
-	var limitSpecs []api.LimitSpec
-	if err := json.Unmarshal([]byte(body), &limitSpecs); err != nil {
-		return []api.LimitSpec{}, -1, err
-	}
-	return limitSpecs, count, reqErr
+	return drycc.List[api.LimitSpec](ctx, c, u, results)
 }
 
 // Plans is list all available limit plans
 func Plans(c *drycc.Client, specID string, cpu, memory, results int) ([]api.LimitPlan, int, error) {
+	return PlansWithContext(context.Background(), c, specID, cpu, memory, results)
+}
+
+// PlansWithContext is like [Plans] but carries ctx.
+func PlansWithContext(ctx context.Context, c *drycc.Client, specID string, cpu, memory, results int) ([]api.LimitPlan, int, error) {
 	var queryArray []string
 	if cpu > 0 {
 		queryArray = append(queryArray, fmt.Sprintf("cpu=%d", cpu))
@@ -44,31 +45,15 @@ func Plans(c *drycc.Client, specID string, cpu, memory, results int) ([]api.Limi
 	if len(queryArray) > 0 {
 		u = fmt.Sprintf("%s?%s", u, strings.Join(queryArray, "&"))
 	}
-
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.LimitPlan{}, -1, reqErr
-	}
-	var limitPlans []api.LimitPlan
-	if err := json.Unmarshal([]byte(body), &limitPlans); err != nil {
-		return []api.LimitPlan{}, -1, err
-	}
-	return limitPlans, count, reqErr
+	return drycc.List[api.LimitPlan](ctx, c, u, results)
 }
 
 // GetPlan is get a available Plan
 func GetPlan(c *drycc.Client, planID string) (api.LimitPlan, error) {
-	u := fmt.Sprintf("/v2/limits/plans/%s/", planID)
-	res, reqErr := c.Request("GET", u, nil)
+	return GetPlanWithContext(context.Background(), c, planID)
+}
 
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.LimitPlan{}, reqErr
-	}
-	defer res.Body.Close()
-	limitPlan := api.LimitPlan{}
-	if err := json.NewDecoder(res.Body).Decode(&limitPlan); err != nil {
-		return api.LimitPlan{}, err
-	}
-	return limitPlan, reqErr
+// GetPlanWithContext is like [GetPlan] but carries ctx.
+func GetPlanWithContext(ctx context.Context, c *drycc.Client, planID string) (api.LimitPlan, error) {
+	return drycc.Get[api.LimitPlan](ctx, c, fmt.Sprintf("/v2/limits/plans/%s/", planID))
 }
@@ -0,0 +1,91 @@
+package drycc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"time"
+)
+
+// Doer sends an HTTP request and returns an HTTP response.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to observe or alter requests and responses. A middleware
+// may return a response without calling next, for example to inject faults.
+type Middleware func(next Doer) Doer
+
+// Use appends middlewares to the chain every request of the client goes through. The
+// first middleware is the outermost one. Middlewares see requests once the SDK has added
+// its headers, and responses before they are turned into errors. When a request is
+// retried, every attempt goes through the chain.
+//
+// Use must not be called while the client is making requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// doer returns the HTTP client wrapped in the middlewares.
+func (c *Client) doer() Doer {
+	var d Doer = DoerFunc(c.httpDo)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		d = c.middlewares[i](d)
+	}
+	return d
+}
+
+var (
+	redactedHeader = regexp.MustCompile(`(?mi)^(Authorization|X-Drycc-Service-Key|Cookie|Set-Cookie):.*$`)
+	redactedToken  = regexp.MustCompile(`"(token|key|password)"\s*:\s*"[^"]*"`)
+)
+
+// redact hides credentials from a dumped request or response.
+func redact(dump []byte) []byte {
+	dump = redactedHeader.ReplaceAll(dump, []byte("$1: REDACTED"))
+	return redactedToken.ReplaceAll(dump, []byte(`"$1":"REDACTED"`))
+}
+
+// DebugMiddleware writes every request and response, including their bodies, to w.
+// Tokens, service keys, passwords and cookies are redacted.
+func DebugMiddleware(w io.Writer) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+				fmt.Fprintf(w, "%s\n", redact(dump))
+			}
+			res, err := next.Do(req)
+			if err != nil {
+				fmt.Fprintf(w, "error: %v\n", err)
+				return res, err
+			}
+			if dump, err := httputil.DumpResponse(res, true); err == nil {
+				fmt.Fprintf(w, "%s\n", redact(dump))
+			}
+			return res, nil
+		})
+	}
+}
+
+// TimingMiddleware calls observe with the duration of every request, measured until the
+// response headers are received.
+func TimingMiddleware(observe func(req *http.Request, res *http.Response, elapsed time.Duration, err error)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+			observe(req, res, time.Since(start), err)
+			return res, err
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package drycc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" in")
+				res, err := next.Do(req)
+				calls = append(calls, name+" out")
+				return res, err
+			})
+		}
+	}
+	// The fake server requires this user agent, so the header injection must reach it.
+	userAgent := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("User-Agent", "test")
+			return next.Do(req)
+		})
+	}
+	drycc.Use(trace("outer"), trace("inner"))
+	drycc.Use(userAgent)
+
+	if _, err = drycc.Request("GET", "/healthz", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"outer in", "inner in", "inner out", "outer out"}
+	if !reflect.DeepEqual(expected, calls) {
+		t.Errorf("Expected %v, Got %v", expected, calls)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc := newRetryClient(t, server.URL, &fakeClock{})
+	drycc.UserAgent = "test"
+
+	failures := 1
+	drycc.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if failures > 0 {
+				failures--
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("injected")),
+					Request:    req,
+				}, nil
+			}
+			return next.Do(req)
+		})
+	})
+
+	// The injected failure is retried.
+	res, err := drycc.Request("GET", "/healthz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if failures != 0 {
+		t.Error("Expected the fault to be injected")
+	}
+}
+
+func TestDebugMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Add("DRYCC_API_VERSION", APIVersion)
+		body, _ := io.ReadAll(req.Body)
+		res.Write([]byte(`{"token": "secret-token", "username": "test", "echo": ` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "secret-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.ServiceKey = "secret-key"
+
+	var out bytes.Buffer
+	drycc.Use(DebugMiddleware(&out))
+
+	res, err := drycc.Request("POST", "/v2/auth/login/", []byte(`{"password":"secret-password"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	// The body must still be readable after being dumped.
+	body, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(body), "secret-token") {
+		t.Errorf("Expected the response body to be intact, Got %s", body)
+	}
+
+	dump := out.String()
+	for _, secret := range []string{"secret-token", "secret-key", "secret-password"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("Expected %s to be redacted from:\n%s", secret, dump)
+		}
+	}
+	for _, expected := range []string{"POST /v2/auth/login/", "Authorization: REDACTED", "200 OK", `"username": "test"`} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, dump)
+		}
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Add("DRYCC_API_VERSION", APIVersion)
+		time.Sleep(10 * time.Millisecond)
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var observed []time.Duration
+	drycc.Use(TimingMiddleware(func(req *http.Request, res *http.Response, elapsed time.Duration, err error) {
+		if err != nil || res.StatusCode != http.StatusNoContent || req.URL.Path != "/v2/apps/example-go/" {
+			t.Errorf("Unexpected request %s: %v", req.URL, err)
+		}
+		observed = append(observed, elapsed)
+	}))
+
+	if _, err = drycc.Request("DELETE", "/v2/apps/example-go/", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(observed) != 1 || observed[0] < 10*time.Millisecond {
+		t.Errorf("Expected a single request of at least 10ms, Got %v", observed)
+	}
+}
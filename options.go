@@ -0,0 +1,74 @@
+package drycc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections to the controller kept
+// open for reuse when keep-alive is enabled.
+const DefaultMaxIdleConnsPerHost = 16
+
+// Option configures a Client created with New.
+type Option func(*options)
+
+type options struct {
+	transport       http.RoundTripper
+	timeout         time.Duration
+	keepAlive       bool
+	maxConnsPerHost int
+	http2           bool
+
+	caPEMs       [][]byte
+	noSystemCAs  bool
+	certificates []tls.Certificate
+	pins         []string
+
+	// err collects the errors of options loading files, reported by New.
+	err error
+}
+
+func defaultOptions() options {
+	return options{keepAlive: true}
+}
+
+// WithTransport makes the client send requests through rt instead of a transport built by
+// the SDK. The other transport options, including SSL verification, don't apply to rt.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithTimeout limits the time a request may take, including reading the response body.
+// A zero timeout, the default, means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithKeepAlive enables or disables reusing connections across requests. Keep-alive is
+// enabled by default.
+func WithKeepAlive(keepAlive bool) Option {
+	return func(o *options) {
+		o.keepAlive = keepAlive
+	}
+}
+
+// WithMaxConnsPerHost limits the number of connections to the controller. Zero, the
+// default, means no limit.
+func WithMaxConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxConnsPerHost = n
+	}
+}
+
+// WithHTTP2 enables or disables negotiating HTTP/2 with the controller. HTTP/2 is
+// disabled by default.
+func WithHTTP2(enabled bool) Option {
+	return func(o *options) {
+		o.http2 = enabled
+	}
+}
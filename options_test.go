@@ -0,0 +1,133 @@
+package drycc
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// countConnections counts the connections opened to server.
+func countConnections(server *httptest.Server) *atomic.Int32 {
+	var count atomic.Int32
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			count.Add(1)
+		}
+	}
+	return &count
+}
+
+func requestTimes(t *testing.T, c *Client, n int) {
+	t.Helper()
+	for range n {
+		res, err := c.Request("GET", "/healthz", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(fakeHTTPServer{Version: APIVersion})
+	connections := countConnections(server)
+	server.Start()
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+
+	requestTimes(t, drycc, 3)
+	if n := connections.Load(); n != 1 {
+		t.Errorf("Expected 1 connection, Got %d", n)
+	}
+}
+
+func TestWithKeepAliveDisabled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(fakeHTTPServer{Version: APIVersion})
+	connections := countConnections(server)
+	server.Start()
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc", WithKeepAlive(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+
+	requestTimes(t, drycc, 3)
+	if n := connections.Load(); n != 3 {
+		t.Errorf("Expected 3 connections, Got %d", n)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	t.Parallel()
+
+	var called atomic.Bool
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		called.Store(true)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Drycc_api_version": {APIVersion}},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	drycc, err := New(true, "drycc.example.com", "abc", WithTransport(rt), WithTimeout(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = drycc.Request("GET", "/v2/apps/", nil); err != nil {
+		t.Fatal(err)
+	}
+	if !called.Load() {
+		t.Error("Expected the custom transport to be used")
+	}
+	if drycc.HTTPClient.Timeout != time.Minute {
+		t.Errorf("Expected 1m timeout, Got %v", drycc.HTTPClient.Timeout)
+	}
+}
+
+func TestTransportOptions(t *testing.T) {
+	t.Parallel()
+
+	drycc, err := New(true, "drycc.example.com", "abc", WithMaxConnsPerHost(4), WithHTTP2(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, ok := drycc.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, Got %T", drycc.HTTPClient.Transport)
+	}
+	if tr.MaxConnsPerHost != 4 || tr.MaxIdleConnsPerHost != 4 {
+		t.Errorf("Expected 4 connections per host, Got %d (%d idle)", tr.MaxConnsPerHost, tr.MaxIdleConnsPerHost)
+	}
+	if !tr.ForceAttemptHTTP2 || tr.DisableKeepAlives {
+		t.Errorf("Expected HTTP/2 with keep-alive, Got %t %t", tr.ForceAttemptHTTP2, tr.DisableKeepAlives)
+	}
+	if tr.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected SSL verification")
+	}
+}
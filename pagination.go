@@ -0,0 +1,167 @@
+package drycc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of results requested per page when iterating over a
+// list endpoint without an explicit page size.
+var DefaultPageSize = 100
+
+// Page is a single page of a paginated controller response.
+type Page struct {
+	// Count is the total number of results across all pages.
+	Count int `json:"count"`
+	// Next is the URL of the next page, empty on the last page.
+	Next string `json:"next"`
+	// Previous is the URL of the previous page, empty on the first page.
+	Previous string `json:"previous"`
+	// Results holds the raw JSON array of results of this page.
+	Results json.RawMessage `json:"results"`
+}
+
+// PageWithContext fetches a single page of at most limit results from a list endpoint.
+// If limit is not positive, the limit is left to the controller, which is useful when
+// path is taken from the Next or Previous link of another page.
+func (c *Client) PageWithContext(ctx context.Context, path string, limit int) (Page, error) {
+	if limit > 0 {
+		u, err := url.Parse(path)
+		if err != nil {
+			return Page{}, err
+		}
+		if len(u.Query()) > 0 {
+			path += "&limit=" + strconv.Itoa(limit)
+		} else {
+			path += "?limit=" + strconv.Itoa(limit)
+		}
+	}
+
+	res, err := c.RequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return Page{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{}
+	if err = json.Unmarshal(body, &page); err != nil {
+		return Page{}, err
+	}
+
+	return page, nil
+}
+
+// Pages returns an iterator over every page of the list endpoint at path, following the
+// Next link of each page until the last one. Each page holds at most pageSize results,
+// or DefaultPageSize if pageSize is not positive.
+//
+// Iteration stops after the first error.
+func (c *Client) Pages(ctx context.Context, path string, pageSize int) iter.Seq2[Page, error] {
+	return func(yield func(Page, error) bool) {
+		c.pages(ctx, path, pageSize, yield)
+	}
+}
+
+// pages walks the pages of path.
+func (c *Client) pages(ctx context.Context, path string, pageSize int, yield func(Page, error) bool) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	limit := pageSize
+	for path != "" {
+		page, err := c.PageWithContext(ctx, path, limit)
+		if err != nil {
+			yield(Page{}, err)
+			return
+		}
+		if !yield(page, nil) {
+			return
+		}
+		if path, err = nextPath(page.Next); err != nil {
+			yield(Page{}, err)
+			return
+		}
+		// Next links already carry the limit and offset.
+		limit = 0
+	}
+}
+
+// nextPath turns an absolute Next link into a path relative to the controller URL, so
+// the request goes through the same host the client is configured with.
+func nextPath(next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+	return u.RequestURI(), nil
+}
+
+// All returns an iterator over every result of the list endpoint at path, decoded as T.
+// Pages of pageSize results are fetched lazily as the iteration proceeds, so breaking out
+// of the loop early stops further requests.
+//
+//	for app, err := range drycc.All[api.App](ctx, client, "/v2/apps/", 100) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(app.ID)
+//	}
+func All[T any](ctx context.Context, c *Client, path string, pageSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		c.pages(ctx, path, pageSize, func(page Page, err error) bool {
+			return yieldResults(page, err, yield)
+		})
+	}
+}
+
+// ListAll collects every result of the list endpoint at path, following Next links.
+func ListAll[T any](ctx context.Context, c *Client, path string, pageSize int) ([]T, error) {
+	items := []T{}
+	var iterErr error
+	c.pages(ctx, path, pageSize, func(page Page, err error) bool {
+		return yieldResults(page, err, func(item T, err error) bool {
+			if err != nil {
+				iterErr = err
+				return false
+			}
+			items = append(items, item)
+			return true
+		})
+	})
+	if iterErr != nil {
+		return []T{}, iterErr
+	}
+	return items, nil
+}
+
+// yieldResults decodes the results of page and passes them to yield one by one.
+func yieldResults[T any](page Page, err error, yield func(T, error) bool) bool {
+	var zero T
+	if err != nil {
+		yield(zero, err)
+		return false
+	}
+	var results []T
+	if err := json.Unmarshal(page.Results, &results); err != nil {
+		yield(zero, err)
+		return false
+	}
+	for _, result := range results {
+		if !yield(result, nil) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type fakePagedServer struct {
+	Version  string
+	requests atomic.Int32
+}
+
+func (f *fakePagedServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	f.requests.Add(1)
+	res.Header().Add("DRYCC_API_VERSION", f.Version)
+
+	if req.URL.Path != "/paged/" || req.Method != "GET" {
+		fmt.Printf("Unrecongized URL %s\n", req.URL)
+		res.WriteHeader(http.StatusNotFound)
+		res.Write(nil)
+		return
+	}
+
+	// The next link points to a different host, which must be ignored.
+	switch req.URL.RawQuery {
+	case "limit=2":
+		res.Write([]byte(`{"count": 5, "next": "http://replaced.com/paged/?limit=2&offset=2", "previous": null,
+			"results": [{"test": "a"}, {"test": "b"}]}`))
+	case "limit=2&offset=2":
+		res.Write([]byte(`{"count": 5, "next": "http://replaced.com/paged/?limit=2&offset=4",
+			"previous": "http://replaced.com/paged/?limit=2", "results": [{"test": "c"}, {"test": "d"}]}`))
+	case "limit=2&offset=4":
+		res.Write([]byte(`{"count": 5, "next": null, "previous": "http://replaced.com/paged/?limit=2&offset=2",
+			"results": [{"test": "e"}]}`))
+	default:
+		fmt.Printf("Unexpected query %s\n", req.URL.RawQuery)
+		res.WriteHeader(http.StatusBadRequest)
+		res.Write(nil)
+	}
+}
+
+type pagedItem struct {
+	Test string `json:"test"`
+}
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	handler := &fakePagedServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []pagedItem{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}}
+	actual, err := ListAll[pagedItem](context.Background(), drycc, "/paged/", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+
+	if requests := handler.requests.Load(); requests != 3 {
+		t.Errorf("Expected 3 requests, Got %d", requests)
+	}
+}
+
+func TestListAllAPIMismatch(t *testing.T) {
+	t.Parallel()
+
+	handler := &fakePagedServer{Version: "3.0"}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := ListAll[pagedItem](context.Background(), drycc, "/paged/", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual) != 5 {
+		t.Errorf("Expected 5 results, Got %d", len(actual))
+	}
+
+	if warnings := drycc.Warnings(); len(warnings) != 1 || !IsErrAPIMismatch(warnings[0].Err) {
+		t.Errorf("Expected an ErrAPIMismatch warning, Got %v", warnings)
+	}
+
+	drycc.Strict = true
+	if _, err = ListAll[pagedItem](context.Background(), drycc, "/paged/", 2); !IsErrAPIMismatch(err) {
+		t.Errorf("Expected %v, Got %v", ErrAPIMismatch, err)
+	}
+}
+
+func TestAllEarlyStop(t *testing.T) {
+	t.Parallel()
+
+	handler := &fakePagedServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []pagedItem
+	for item, err := range All[pagedItem](context.Background(), drycc, "/paged/", 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, item)
+		if item.Test == "c" {
+			break
+		}
+	}
+
+	expected := []pagedItem{{"a"}, {"b"}, {"c"}}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+
+	if requests := handler.requests.Load(); requests != 2 {
+		t.Errorf("Expected 2 requests, Got %d", requests)
+	}
+}
+
+func TestPagesError(t *testing.T) {
+	t.Parallel()
+
+	handler := &fakePagedServer{Version: APIVersion}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := 0
+	for _, err := range drycc.Pages(context.Background(), "/missing/", 2) {
+		pages++
+		if !errors.As(err, &ErrNotFound{}) {
+			t.Errorf("Expected ErrNotFound, Got %v", err)
+		}
+	}
+
+	if pages != 1 {
+		t.Errorf("Expected a single error, Got %d pages", pages)
+	}
+}
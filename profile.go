@@ -0,0 +1,191 @@
+package drycc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultProfile is the name of the profile used when none is given.
+	DefaultProfile = "client"
+
+	profileEnv       = "DRYCC_PROFILE"
+	controllerURLEnv = "DRYCC_CONTROLLER_URL"
+	tokenEnv         = "DRYCC_TOKEN"
+)
+
+// Profile holds the client settings written by the Drycc CLI, one JSON file per profile
+// under ~/.drycc. The default profile is stored in ~/.drycc/client.json.
+type Profile struct {
+	// Name is the name of the profile.
+	Name string `json:"-"`
+	// Path is the file the profile is read from and saved to.
+	Path string `json:"-"`
+
+	Username      string `json:"username"`
+	SSLVerify     bool   `json:"ssl_verify"`
+	Controller    string `json:"controller"`
+	Token         string `json:"token"`
+	ResponseLimit int    `json:"response_limit"`
+
+	// Store, if set, keeps the token instead of the profile file, see LoadConfigWithStore.
+	Store CredentialStore `json:"-"`
+	// KeepToken also writes the token to the profile file when saving it with a Store. The
+	// Drycc CLI reads the token from the file, so it is logged out of a profile saved with
+	// a Store unless KeepToken is set, at the cost of a plaintext copy of the token.
+	KeepToken bool `json:"-"`
+}
+
+// ConfigDir returns the directory holding the client profiles, ~/.drycc.
+func ConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".drycc"), nil
+}
+
+// profilePath resolves a profile name to its file. A name that is a path to a JSON file
+// is used as is, which is how the Drycc CLI treats DRYCC_PROFILE.
+func profilePath(name string) (string, string, error) {
+	if strings.HasSuffix(name, ".json") || strings.ContainsRune(name, os.PathSeparator) {
+		return strings.TrimSuffix(filepath.Base(name), ".json"), name, nil
+	}
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", "", err
+	}
+	return name, filepath.Join(dir, name+".json"), nil
+}
+
+// LoadConfig reads the named profile. If name is empty, the profile is taken from the
+// DRYCC_PROFILE environment variable, falling back to DefaultProfile.
+//
+// A profile that doesn't exist yet is returned empty, with SSL verification enabled,
+// so that it can be filled in and saved.
+func LoadConfig(name string) (*Profile, error) {
+	return LoadConfigWithStore(name, nil)
+}
+
+// LoadConfigWithStore is like [LoadConfig] but keeps the token in store. A profile
+// without a token, such as one saved with a store, takes it from store, and Save moves the
+// token of the profile from the file to store.
+func LoadConfigWithStore(name string, store CredentialStore) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(profileEnv)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	name, path, err := profilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{Name: name, Path: path, SSLVerify: true, Store: store}
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return profile, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(contents, profile); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", path, err)
+	}
+	if store != nil && profile.Token == "" && profile.Controller != "" {
+		profile.Token, err = store.Get(profile.Controller, profile.Username)
+		if err != nil && !errors.Is(err, ErrNoCredential) {
+			return nil, fmt.Errorf("profile %s: %w", path, err)
+		}
+	}
+	return profile, nil
+}
+
+// ListProfiles returns the names of the profiles stored in ConfigDir, sorted.
+func ListProfiles() ([]string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Save writes the profile back to its file, for example after a new token was obtained
+// with auth.Token. The file is only readable by the current user, as it holds the token.
+// If the profile has a Store, the token is written to it instead, and to the file as well
+// with KeepToken.
+func (p *Profile) Save() error {
+	saved := *p
+	if p.Store != nil && p.Token != "" {
+		if err := p.Store.Set(p.Controller, p.Username, p.Token); err != nil {
+			return err
+		}
+		if !p.KeepToken {
+			saved.Token = ""
+		}
+	}
+	contents, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(p.Path, contents, 0o600)
+}
+
+// NewClient creates a client from the profile. The DRYCC_CONTROLLER_URL and DRYCC_TOKEN
+// environment variables take precedence over the controller and token of the profile,
+// without being written to it.
+func (p *Profile) NewClient() (*Client, error) {
+	controller := p.Controller
+	if v := os.Getenv(controllerURLEnv); v != "" {
+		controller = v
+	}
+	token := p.Token
+	if v := os.Getenv(tokenEnv); v != "" {
+		token = v
+	}
+	if controller == "" {
+		return nil, fmt.Errorf("profile %s has no controller, set %s or log in", p.Name, controllerURLEnv)
+	}
+	return New(p.SSLVerify, controller, token)
+}
+
+// NewFromProfile loads the named profile with LoadConfig and creates a client from it.
+// The profile is returned as well, so that updated settings can be saved back.
+func NewFromProfile(name string) (*Client, *Profile, error) {
+	return NewFromProfileWithStore(name, nil)
+}
+
+// NewFromProfileWithStore is like [NewFromProfile] but loads the profile with
+// LoadConfigWithStore, keeping its token in store.
+func NewFromProfileWithStore(name string, store CredentialStore) (*Client, *Profile, error) {
+	profile, err := LoadConfigWithStore(name, store)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := profile.NewClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, profile, nil
+}
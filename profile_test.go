@@ -0,0 +1,196 @@
+package drycc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const profileFixture = `{
+  "username": "test",
+  "ssl_verify": false,
+  "controller": "http://drycc.example.com",
+  "token": "abc",
+  "response_limit": 50
+}`
+
+func writeProfile(t *testing.T, home, name, contents string) {
+	t.Helper()
+	dir := filepath.Join(home, ".drycc")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DRYCC_PROFILE", "")
+	writeProfile(t, home, "client", profileFixture)
+
+	profile, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Profile{
+		Name:          "client",
+		Path:          filepath.Join(home, ".drycc", "client.json"),
+		Username:      "test",
+		SSLVerify:     false,
+		Controller:    "http://drycc.example.com",
+		Token:         "abc",
+		ResponseLimit: 50,
+	}
+	if !reflect.DeepEqual(expected, profile) {
+		t.Errorf("Expected %v, Got %v", expected, profile)
+	}
+}
+
+func TestLoadConfigNamedProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeProfile(t, home, "client", profileFixture)
+	writeProfile(t, home, "staging", `{"controller": "https://staging.example.com", "token": "def", "ssl_verify": true}`)
+
+	t.Setenv("DRYCC_PROFILE", "staging")
+	profile, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != "staging" || profile.Token != "def" {
+		t.Errorf("Expected the staging profile, Got %v", profile)
+	}
+
+	// An explicit path works like the Drycc CLI.
+	path := filepath.Join(home, ".drycc", "client.json")
+	if profile, err = LoadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != "client" || profile.Path != path {
+		t.Errorf("Expected the client profile, Got %v", profile)
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"client", "staging"}; !reflect.DeepEqual(expected, names) {
+		t.Errorf("Expected %v, Got %v", expected, names)
+	}
+}
+
+func TestNewFromProfileEnvOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DRYCC_PROFILE", "")
+	writeProfile(t, home, "client", profileFixture)
+
+	t.Setenv("DRYCC_CONTROLLER_URL", "http://other.example.com")
+	t.Setenv("DRYCC_TOKEN", "xyz")
+
+	client, profile, err := NewFromProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.ControllerURL.String() != "http://other.example.com" {
+		t.Errorf("Expected the controller from the environment, Got %s", client.ControllerURL)
+	}
+	if client.Token != "xyz" || client.VerifySSL {
+		t.Errorf("Expected token xyz without SSL verification, Got %s %t", client.Token, client.VerifySSL)
+	}
+	// Overrides are not written to the profile.
+	if profile.Token != "abc" {
+		t.Errorf("Expected abc, Got %s", profile.Token)
+	}
+}
+
+func TestProfileSave(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DRYCC_CONTROLLER_URL", "")
+	t.Setenv("DRYCC_TOKEN", "")
+
+	profile, err := LoadConfig("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !profile.SSLVerify {
+		t.Error("Expected SSL verification for a new profile")
+	}
+	if _, err = profile.NewClient(); err == nil {
+		t.Error("Expected an error for a profile without a controller")
+	}
+
+	profile.Controller = "drycc.example.com"
+	profile.Username = "test"
+	profile.Token = "abc"
+	if err = profile.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(profile.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected 0600, Got %o", perm)
+	}
+
+	saved, err := LoadConfig("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(profile, saved) {
+		t.Errorf("Expected %v, Got %v", profile, saved)
+	}
+}
+
+func TestProfileStore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DRYCC_PROFILE", "")
+	writeProfile(t, home, "client", profileFixture)
+	store := NewMemoryStore()
+
+	// KeepToken copies the token of the profile to the store, leaving the CLI logged in.
+	profile, err := LoadConfigWithStore("", store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile.KeepToken = true
+	if err = profile.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := store.Get("drycc.example.com", "test"); err != nil || token != "abc" {
+		t.Errorf("Expected abc, Got %s (%v)", token, err)
+	}
+	if plain, err := LoadConfig(""); err != nil || plain.Token != "abc" {
+		t.Errorf("Expected abc in the profile, Got %s (%v)", plain.Token, err)
+	}
+
+	// Saving moves it to the store by default.
+	profile.KeepToken = false
+	if err = profile.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := LoadConfig(""); err != nil || plain.Token != "" {
+		t.Errorf("Expected no token in the profile, Got %s (%v)", plain.Token, err)
+	}
+
+	client, loaded, err := NewFromProfileWithStore("", store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Token != "abc" || client.CurrentToken() != "abc" {
+		t.Errorf("Expected abc, Got %s and %s", loaded.Token, client.CurrentToken())
+	}
+
+	if _, err = LoadConfigWithStore("", NewEncryptedFileStore(filepath.Join(home, "credentials"), "secret")); err != nil {
+		t.Errorf("Expected a profile without a stored token, Got %v", err)
+	}
+}
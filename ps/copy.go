@@ -0,0 +1,275 @@
+package ps
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// CopyOptions configures CopyTo and CopyFrom.
+type CopyOptions struct {
+	// Progress, if set, is called as the content of each regular file is copied, with the
+	// name of the file in the archive, the bytes copied so far and the size of the file.
+	Progress func(name string, copied, size int64)
+}
+
+// CopyTo copies a local file or directory to a path of a pod, like kubectl cp, by running
+// tar in the pod through Exec. The container must have tar, and the parent directory of
+// dst must exist. Modes and symbolic links are preserved, subject to the umask of the
+// user running tar in the pod.
+func CopyTo(ctx context.Context, c *drycc.Client, appID, podID, src, dst string, opts CopyOptions) error {
+	dst = path.Clean(dst)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, src, path.Base(dst), opts.Progress))
+	}()
+	defer pr.Close()
+
+	output := &bytes.Buffer{}
+	command := api.Command{Stdin: true, Command: []string{"tar", "-xmf", "-", "-C", path.Dir(dst)}}
+	s, err := startExec(ctx, c, appID, podID, command, output, output)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(s.Stdin, pr); err != nil {
+		s.Close()
+		s.Wait()
+		return err
+	}
+	if err := s.Stdin.Close(); err != nil {
+		s.Close()
+		s.Wait()
+		return err
+	}
+	return tarStatus(s, output)
+}
+
+// CopyFrom copies a file or directory of a pod to a local path, like kubectl cp, by
+// running tar in the pod through Exec. The container must have tar, and the parent
+// directory of dst must exist. Modes, modification times and symbolic links are
+// preserved, though links can't be followed out of the parent directory of dst.
+func CopyFrom(ctx context.Context, c *drycc.Client, appID, podID, src, dst string, opts CopyOptions) error {
+	src = path.Clean(src)
+	pr, pw := io.Pipe()
+	stderr := &bytes.Buffer{}
+	command := api.Command{Command: []string{"tar", "cf", "-", "-C", path.Dir(src), path.Base(src)}}
+	s, err := startExec(ctx, c, appID, podID, command, pw, stderr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-s.done
+		pw.Close()
+	}()
+
+	extractErr := extractTar(pr, filepath.Dir(dst), path.Base(src), filepath.Base(dst), opts.Progress)
+	if extractErr == nil {
+		// The archive may be padded after its end.
+		_, extractErr = io.Copy(io.Discard, pr)
+	}
+	// Closing the pipe unblocks the session if extracting failed.
+	pr.Close()
+	code, err := s.Wait()
+	if extractErr != nil {
+		// The session then fails writing to the closed pipe, while a failure of tar may
+		// explain why the archive is truncated.
+		if err == nil && code != 0 {
+			return fmt.Errorf("%w (%w)", extractErr, tarExit(code, stderr))
+		}
+		return extractErr
+	}
+	if err != nil {
+		return err
+	}
+	// Failures of tar explain why the archive is missing.
+	return tarExit(code, stderr)
+}
+
+// tarStatus waits for tar to exit and returns its failure with the output it reported.
+func tarStatus(s *ExecSession, output *bytes.Buffer) error {
+	code, err := s.Wait()
+	if err != nil {
+		return err
+	}
+	return tarExit(code, output)
+}
+
+// tarExit returns the failure of tar exiting with code, or nil if it succeeded.
+func tarExit(code int, output *bytes.Buffer) error {
+	if code != 0 {
+		return fmt.Errorf("tar exited with code %d: %s", code, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// writeTar archives the local file or directory src, naming it name in the archive.
+func writeTar(w io.Writer, src, name string, progress func(string, int64, int64)) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(progressWriter(tw, hdr, progress), f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractTar extracts an archive into the local directory dir, renaming its entry from to
+// to. Entries outside of from are an error, unless from is empty, which keeps the names.
+func extractTar(r io.Reader, dir, from, to string, progress func(string, int64, int64)) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	// Directories are only made read-only once their content is extracted.
+	type dirEntry struct {
+		name string
+		hdr  *tar.Header
+	}
+	var dirs []dirEntry
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if from != "" {
+			rest, ok := strings.CutPrefix(name, from)
+			if !ok || rest != "" && !strings.HasPrefix(rest, "/") {
+				return fmt.Errorf("unexpected entry %q in archive of %s", hdr.Name, from)
+			}
+			name = to + rest
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("unsafe entry %q in archive", hdr.Name)
+		}
+		name = filepath.FromSlash(name)
+		mode := hdr.FileInfo().Mode()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(name, 0o700); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirEntry{name, hdr})
+			continue
+		case tar.TypeReg:
+			root.Remove(name)
+			f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(progressWriter(f, hdr, progress), tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			root.Remove(name)
+			if err := root.Symlink(hdr.Linkname, name); err != nil {
+				return err
+			}
+			continue
+		default:
+			// Devices, pipes and hard links aren't copied, like kubectl cp.
+			continue
+		}
+		if err := root.Chmod(name, mode.Perm()); err != nil {
+			return err
+		}
+		if err := root.Chtimes(name, hdr.ModTime, hdr.ModTime); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range slices.Backward(dirs) {
+		if err := root.Chmod(d.name, d.hdr.FileInfo().Mode().Perm()); err != nil {
+			return err
+		}
+		if err := root.Chtimes(d.name, d.hdr.ModTime, d.hdr.ModTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// progressWriter reports the content of a file written to w to progress, if set.
+func progressWriter(w io.Writer, hdr *tar.Header, progress func(string, int64, int64)) io.Writer {
+	if progress == nil {
+		return w
+	}
+	progress(hdr.Name, 0, hdr.Size)
+	return &copyProgress{w: w, name: hdr.Name, size: hdr.Size, progress: progress}
+}
+
+type copyProgress struct {
+	w        io.Writer
+	name     string
+	copied   int64
+	size     int64
+	progress func(string, int64, int64)
+}
+
+func (p *copyProgress) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.copied += int64(n)
+	p.progress(p.name, p.copied, p.size)
+	return n, err
+}
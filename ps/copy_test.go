@@ -0,0 +1,202 @@
+package ps
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/drycc/controller-sdk-go/drycctest"
+)
+
+// fakeTar runs the tar commands of CopyTo and CopyFrom in a pod whose filesystem is root.
+func fakeTar(root string) drycctest.ExecFunc {
+	return func(e *drycctest.Exec) int {
+		argv := e.Command.Command
+		var err error
+		switch {
+		case slices.Equal(argv[:len(argv)-1], []string{"tar", "-xmf", "-", "-C"}):
+			err = extractTar(e.Stdin, filepath.Join(root, argv[4]), "", "", nil)
+		case slices.Equal(argv[:len(argv)-2], []string{"tar", "cf", "-", "-C"}):
+			err = writeTar(e.Stdout, filepath.Join(root, argv[4], argv[5]), argv[5], nil)
+		default:
+			return 127
+		}
+		if err != nil {
+			fmt.Fprintln(e.Stderr, "tar:", err)
+			return 2
+		}
+		return 0
+	}
+}
+
+// listTree describes the files of a directory, with their modes and content or target.
+func listTree(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var tree []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		entry := fmt.Sprintf("%s %s", rel, info.Mode())
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			entry += " -> " + link
+		case info.Mode().IsRegular():
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			entry += fmt.Sprintf(" %d bytes %x", len(data), data[:min(len(data), 8)])
+		}
+		tree = append(tree, entry)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	local := t.TempDir()
+	src := filepath.Join(local, "fixtures")
+	large := make([]byte, 3<<20)
+	rand.Read(large)
+	for _, dir := range []string{"fixtures", "fixtures/sub"} {
+		if err := os.Mkdir(filepath.Join(local, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := []struct {
+		name string
+		data []byte
+		mode os.FileMode
+	}{
+		{"fixtures/data.json", []byte(`{"key": "value"}`), 0o644},
+		{"fixtures/run.sh", []byte("#!/bin/sh\n"), 0o755},
+		{"fixtures/sub/heap.dump", large, 0o600},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(local, f.name), f.data, f.mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(filepath.Join(local, f.name), f.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("../data.json", filepath.Join(src, "sub", "data.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(src, "sub"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	pod := t.TempDir()
+	if err := os.Mkdir(filepath.Join(pod, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetExec(fakeTar(pod)) })
+	ctx := context.Background()
+
+	progress := map[string][2]int64{}
+	opts := CopyOptions{Progress: func(name string, copied, size int64) {
+		progress[name] = [2]int64{copied, size}
+	}}
+	if err := CopyTo(ctx, client, "example-go", "example-go-web-111", src, "/data/copy", opts); err != nil {
+		t.Fatal(err)
+	}
+	expected := listTree(t, src)
+	if actual := listTree(t, filepath.Join(pod, "data", "copy")); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+	if p := progress["copy/sub/heap.dump"]; p != [2]int64{3 << 20, 3 << 20} {
+		t.Errorf("Expected the progress of %d bytes, Got %v", 3<<20, p)
+	}
+
+	clear(progress)
+	dst := filepath.Join(local, "copied")
+	if err := CopyFrom(ctx, client, "example-go", "example-go-web-111", "/data/copy/", dst, opts); err != nil {
+		t.Fatal(err)
+	}
+	if actual := listTree(t, dst); !slices.Equal(actual, expected) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+	if p := progress["copy/sub/heap.dump"]; p != [2]int64{3 << 20, 3 << 20} {
+		t.Errorf("Expected the progress of %d bytes, Got %v", 3<<20, p)
+	}
+
+	// Single files are copied too.
+	if err := CopyFrom(ctx, client, "example-go", "example-go-web-111", "/data/copy/run.sh", filepath.Join(local, "run.sh"), CopyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filepath.Join(local, "run.sh")); err != nil || info.Mode() != 0o755 {
+		t.Errorf("Expected %v, Got %v (%v)", os.FileMode(0o755), info, err)
+	}
+
+	err := CopyFrom(ctx, client, "example-go", "example-go-web-111", "/data/missing", filepath.Join(local, "missing"), CopyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "tar exited with code 2") {
+		t.Errorf("Expected tar to fail, Got %v", err)
+	}
+	// Local failures aren't hidden by the session failing once extracting stops.
+	err = CopyFrom(ctx, client, "example-go", "example-go-web-111", "/data/copy/", filepath.Join(local, "missing", "copy"), CopyOptions{})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected %v, Got %v", fs.ErrNotExist, err)
+	}
+	if err := os.WriteFile(filepath.Join(local, "file"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = CopyFrom(ctx, client, "example-go", "example-go-web-111", "/data/copy/", filepath.Join(local, "file", "copy"), CopyOptions{})
+	if err == nil || strings.Contains(err.Error(), "closed pipe") {
+		t.Errorf("Expected the destination to be rejected, Got %v", err)
+	}
+}
+
+func TestExtractTarUnsafe(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"copy/../../evil", "other/file"} {
+		archive := &bytes.Buffer{}
+		tw := tar.NewWriter(archive)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 4, Typeflag: tar.TypeReg})
+		tw.Write([]byte("evil"))
+		tw.Close()
+
+		dir := t.TempDir()
+		if err := extractTar(archive, dir, "copy", "copy", nil); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+
+	// Files can't be written through links out of the directory.
+	archive := &bytes.Buffer{}
+	tw := tar.NewWriter(archive)
+	tw.WriteHeader(&tar.Header{Name: "copy/", Mode: 0o755, Typeflag: tar.TypeDir})
+	tw.WriteHeader(&tar.Header{Name: "copy/link", Linkname: "/tmp", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "copy/link/evil", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg})
+	tw.Write([]byte("evil"))
+	tw.Close()
+	if err := extractTar(archive, t.TempDir(), "copy", "copy", nil); err == nil {
+		t.Error("Expected an error for a file out of the directory")
+	}
+}
@@ -0,0 +1,228 @@
+package ps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+// The exec endpoint relays the streams of the command in binary messages whose first byte
+// is the stream, like the channel protocol of Kubernetes.
+const (
+	streamStdin  byte = 0
+	streamStdout byte = 1
+	streamStderr byte = 2
+	streamStatus byte = 3
+	streamResize byte = 4
+	// streamClose closes the stream in the second byte of the message.
+	streamClose byte = 255
+)
+
+// ErrNoExitStatus is returned by ExecSession.Wait when the connection was closed before the
+// controller reported the exit status of the command.
+var ErrNoExitStatus = errors.New("exec session ended without an exit status")
+
+// execStatus is the status of a command reported once it has exited.
+type execStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Details struct {
+		Causes []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"causes"`
+	} `json:"details"`
+}
+
+// exitCode returns the exit code of the command. A failure other than a non-zero exit
+// code, such as a missing executable, is returned as an error.
+func (s execStatus) exitCode() (int, error) {
+	if s.Status == "Success" {
+		return 0, nil
+	}
+	if s.Reason == "NonZeroExitCode" {
+		for _, cause := range s.Details.Causes {
+			if cause.Reason == "ExitCode" {
+				if code, err := strconv.Atoi(cause.Message); err == nil {
+					return code, nil
+				}
+			}
+		}
+	}
+	return -1, fmt.Errorf("exec failed: %s", s.Message)
+}
+
+// ExecSession is a command running in a container, started with NewExecSession.
+//
+// Stdout and Stderr must both be read until EOF, for example with io.Copy in goroutines,
+// as the session blocks until output is consumed. With a TTY, all output goes to Stdout.
+type ExecSession struct {
+	// Stdin is the standard input of the command, if started with Stdin. Closing it sends
+	// EOF to the command.
+	Stdin io.WriteCloser
+	// Stdout is the standard output of the command.
+	Stdout io.Reader
+	// Stderr is the standard error of the command.
+	Stderr io.Reader
+
+	conn   *websocket.Conn
+	sendMu sync.Mutex
+	stop   func() bool
+	// output holds the pipes of Stdout and Stderr, closed by Close.
+	output []io.Closer
+	done   chan struct{}
+	code   int
+	err    error
+}
+
+// NewExecSession runs a command in a container of a pod and returns the session to
+// interact with it.
+func NewExecSession(ctx context.Context, c *drycc.Client, appID, podID string, command api.Command) (*ExecSession, error) {
+	stdout, stdoutW := io.Pipe()
+	stderr, stderrW := io.Pipe()
+	s, err := startExec(ctx, c, appID, podID, command, stdoutW, stderrW)
+	if err != nil {
+		return nil, err
+	}
+	s.Stdout, s.Stderr = stdout, stderr
+	s.output = []io.Closer{stdout, stderr}
+	go func() {
+		<-s.done
+		stdoutW.Close()
+		stderrW.Close()
+	}()
+	return s, nil
+}
+
+// startExec runs a command, copying its output to stdout and stderr.
+func startExec(ctx context.Context, c *drycc.Client, appID, podID string, command api.Command, stdout, stderr io.Writer) (*ExecSession, error) {
+	conn, err := ExecWithContext(ctx, c, appID, podID, command)
+	if err != nil {
+		return nil, err
+	}
+	s := &ExecSession{
+		conn: conn,
+		stop: context.AfterFunc(ctx, func() { conn.Close() }),
+		done: make(chan struct{}),
+		code: -1,
+	}
+	if command.Stdin {
+		s.Stdin = &execStdin{s}
+	}
+	go s.receive(ctx, stdout, stderr)
+	return s, nil
+}
+
+// receive copies the output of the command until it exits or the connection is closed.
+func (s *ExecSession) receive(ctx context.Context, stdout, stderr io.Writer) {
+	defer close(s.done)
+	s.err = ErrNoExitStatus
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(s.conn, &msg); err != nil {
+			if ctx.Err() != nil {
+				s.err = ctx.Err()
+			} else if !errors.Is(err, io.EOF) {
+				s.err = err
+			}
+			return
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		var err error
+		switch msg[0] {
+		case streamStdout:
+			_, err = stdout.Write(msg[1:])
+		case streamStderr:
+			_, err = stderr.Write(msg[1:])
+		case streamStatus:
+			status := execStatus{}
+			if err = json.Unmarshal(msg[1:], &status); err == nil {
+				s.code, s.err = status.exitCode()
+			}
+			if err == nil {
+				return
+			}
+		}
+		if err != nil {
+			s.err = err
+			return
+		}
+	}
+}
+
+func (s *ExecSession) send(stream byte, data []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return websocket.Message.Send(s.conn, append([]byte{stream}, data...))
+}
+
+// Resize changes the size of the terminal of a command started with Tty, typically when
+// the local terminal receives SIGWINCH.
+func (s *ExecSession) Resize(size api.TerminalSize) error {
+	data, err := json.Marshal(size)
+	if err != nil {
+		return err
+	}
+	return s.send(streamResize, data)
+}
+
+// Wait waits for the command to exit and returns its exit code. An error is returned if
+// the command couldn't run or the connection failed, with an exit code of -1.
+func (s *ExecSession) Wait() (int, error) {
+	<-s.done
+	s.stop()
+	s.conn.Close()
+	return s.code, s.err
+}
+
+// Close closes the connection, terminating the session, and Stdout and Stderr.
+func (s *ExecSession) Close() error {
+	s.stop()
+	err := s.conn.Close()
+	for _, c := range s.output {
+		c.Close()
+	}
+	return err
+}
+
+// execStdin sends the standard input of a command.
+type execStdin struct {
+	s *ExecSession
+}
+
+func (w *execStdin) Write(p []byte) (int, error) {
+	if err := w.s.send(streamStdin, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *execStdin) Close() error {
+	return w.s.send(streamClose, []byte{streamStdin})
+}
+
+// RunCommand runs a command in a container of a pod without input and returns its output,
+// standard output and error interleaved, along with its exit code. A non-zero exit code
+// isn't an error.
+func RunCommand(ctx context.Context, c *drycc.Client, appID, podID string, argv []string) ([]byte, int, error) {
+	// The output is written by a single goroutine, in the order it is received.
+	out := &bytes.Buffer{}
+	s, err := startExec(ctx, c, appID, podID, api.Command{Command: argv}, out, out)
+	if err != nil {
+		return nil, -1, err
+	}
+	code, err := s.Wait()
+	return out.Bytes(), code, err
+}
@@ -0,0 +1,132 @@
+package ps
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/drycctest"
+)
+
+// fakeShell runs a few commands of a shell.
+func fakeShell(e *drycctest.Exec) int {
+	argv := e.Command.Command
+	switch argv[0] {
+	case "echo":
+		fmt.Fprintln(e.Stdout, strings.Join(argv[1:], " "))
+		return 0
+	case "fail":
+		fmt.Fprintln(e.Stdout, "some output")
+		fmt.Fprintln(e.Stderr, "failed")
+		return 3
+	case "cat":
+		io.Copy(e.Stdout, e.Stdin)
+		return 0
+	case "stty":
+		// Reports the terminal sizes until stdin is closed.
+		go io.Copy(io.Discard, e.Stdin)
+		for {
+			select {
+			case size := <-e.Resize:
+				fmt.Fprintf(e.Stdout, "%d %d\n", size.Height, size.Width)
+			case <-e.Context.Done():
+				return 0
+			}
+		}
+	}
+	return 127
+}
+
+func TestRunCommand(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetExec(fakeShell) })
+	ctx := context.Background()
+
+	out, code, err := RunCommand(ctx, client, "example-go", "example-go-web-111", []string{"echo", "hello", "world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "hello world\n" || code != 0 {
+		t.Errorf("Expected %q and 0, Got %q and %d", "hello world\n", out, code)
+	}
+
+	out, code, err = RunCommand(ctx, client, "example-go", "example-go-web-111", []string{"fail"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "some output\nfailed\n" || code != 3 {
+		t.Errorf("Expected %q and 3, Got %q and %d", "some output\nfailed\n", out, code)
+	}
+
+	if _, code, err = RunCommand(ctx, client, "example-go", "missing", []string{"echo"}); err == nil || code != -1 {
+		t.Errorf("Expected an error for a missing pod, Got %d (%v)", code, err)
+	}
+}
+
+func TestExecSession(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetExec(fakeShell) })
+
+	s, err := NewExecSession(context.Background(), client, "example-go", "example-go-web-111",
+		api.Command{Stdin: true, Command: []string{"cat"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go io.Copy(io.Discard, s.Stderr)
+
+	fmt.Fprintln(s.Stdin, "first")
+	out := bufio.NewReader(s.Stdout)
+	if line, err := out.ReadString('\n'); err != nil || line != "first\n" {
+		t.Errorf("Expected %q, Got %q (%v)", "first\n", line, err)
+	}
+	fmt.Fprintln(s.Stdin, "second")
+	if err := s.Stdin.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rest, err := io.ReadAll(out); err != nil || string(rest) != "second\n" {
+		t.Errorf("Expected %q, Got %q (%v)", "second\n", rest, err)
+	}
+
+	if code, err := s.Wait(); err != nil || code != 0 {
+		t.Errorf("Expected 0, Got %d (%v)", code, err)
+	}
+}
+
+func TestExecSessionResize(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetExec(fakeShell) })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewExecSession(ctx, client, "example-go", "example-go-web-111",
+		api.Command{Tty: true, Stdin: true, Command: []string{"stty"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go io.Copy(io.Discard, s.Stderr)
+
+	out := bufio.NewReader(s.Stdout)
+	for _, size := range []api.TerminalSize{{Width: 80, Height: 24}, {Width: 120, Height: 40}} {
+		if err := s.Resize(size); err != nil {
+			t.Fatal(err)
+		}
+		expected := fmt.Sprintf("%d %d\n", size.Height, size.Width)
+		if line, err := out.ReadString('\n'); err != nil || line != expected {
+			t.Errorf("Expected %q, Got %q (%v)", expected, line, err)
+		}
+	}
+
+	// Canceling the context ends the session.
+	cancel()
+	if code, err := s.Wait(); !errors.Is(err, context.Canceled) || code != -1 {
+		t.Errorf("Expected %v, Got %d (%v)", context.Canceled, code, err)
+	}
+}
@@ -0,0 +1,302 @@
+package ps
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultLogsPollInterval is how often the pods of an app are listed to follow new pods,
+	// unless changed in LogsOptions.
+	DefaultLogsPollInterval = 5 * time.Second
+	// DefaultLogsReconnectDelay is how long a disconnected log stream waits before
+	// reconnecting, unless changed in LogsOptions.
+	DefaultLogsReconnectDelay = time.Second
+)
+
+// LogLine is a line logged by a container of a pod.
+type LogLine struct {
+	Pod       string
+	Container string
+	// Timestamp is the time the line was logged, or zero if the controller didn't
+	// prefix the line with one.
+	Timestamp time.Time
+	Text      string
+}
+
+// LogsOptions selects the logs streamed by StreamLogs.
+type LogsOptions struct {
+	// Ptype limits the logs to the pods of a process type. If empty, every pod of the app
+	// is streamed.
+	Ptype string
+	// Container selects the container of the pods. If empty, the controller picks the
+	// main container.
+	Container string
+	// Lines is the number of past lines of each pod to start with.
+	Lines int
+	// Follow keeps streaming new lines, including from pods started later, and reconnects
+	// streams that get disconnected, skipping the timestamped lines already yielded.
+	// Lines without a timestamp can't be told apart, so the past ones are yielded again
+	// after reconnecting. Otherwise the stream ends once the past lines of every pod are
+	// read.
+	Follow bool
+	// PollInterval is how often pods are listed when following. It defaults to
+	// DefaultLogsPollInterval.
+	PollInterval time.Duration
+	// ReconnectDelay is how long a disconnected stream waits before reconnecting. It
+	// defaults to DefaultLogsReconnectDelay.
+	ReconnectDelay time.Duration
+}
+
+// StreamLogs returns an iterator over the log lines of the pods of an app, fanned in from
+// the stream of each pod. Lines of a pod are yielded in order, while lines of different
+// pods are interleaved as they arrive.
+//
+// Errors listing the pods, or opening the stream of a pod without Follow, are yielded and
+// end the iteration. With Follow, errors listing the pods are yielded without ending the
+// iteration, and the pods are listed again after PollInterval, so that streams survive a
+// rollout of the controller. Stopping the iteration or canceling ctx closes the streams.
+//
+// The app-wide log stream of the controller, see api.AppLogsRequest, isn't used, as it
+// can't select a process type or a container, nor be resumed pod by pod after a
+// disconnection.
+func StreamLogs(ctx context.Context, c *drycc.Client, appID string, opts LogsOptions) iter.Seq2[LogLine, error] {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultLogsPollInterval
+	}
+	if opts.ReconnectDelay <= 0 {
+		opts.ReconnectDelay = DefaultLogsReconnectDelay
+	}
+	return func(yield func(LogLine, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		f := &logsFanIn{
+			c: c, appID: appID, opts: opts,
+			lines:    make(chan LogLine),
+			errs:     make(chan error, 1),
+			pollErrs: make(chan error),
+			pods:     map[string]context.CancelFunc{},
+		}
+		go f.run(ctx)
+		for {
+			select {
+			case line, ok := <-f.lines:
+				if !ok {
+					// A stream may have failed just before the others ended.
+					select {
+					case err := <-f.errs:
+						yield(LogLine{}, err)
+					default:
+					}
+					return
+				}
+				if !yield(line, nil) {
+					return
+				}
+			case err := <-f.errs:
+				yield(LogLine{}, err)
+				return
+			case err := <-f.pollErrs:
+				if !yield(LogLine{}, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// logsFanIn streams the logs of the pods of an app into lines.
+type logsFanIn struct {
+	c     *drycc.Client
+	appID string
+	opts  LogsOptions
+	lines chan LogLine
+	errs  chan error
+	// pollErrs receives the errors listing pods when following, which don't end the
+	// stream.
+	pollErrs chan error
+	wg       sync.WaitGroup
+	// pods holds the streams of the pods being followed.
+	pods map[string]context.CancelFunc
+}
+
+func (f *logsFanIn) fail(err error) {
+	select {
+	case f.errs <- err:
+	default:
+	}
+}
+
+// run starts the streams of the pods, and of new pods when following, closing lines once
+// every stream has ended.
+func (f *logsFanIn) run(ctx context.Context) {
+	defer close(f.lines)
+	defer f.wg.Wait()
+
+	ticker := time.NewTicker(f.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		err := f.poll(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case !f.opts.Follow:
+			if err != nil {
+				f.fail(err)
+			}
+			return
+		case err != nil:
+			// The pods are listed again on the next tick.
+			select {
+			case f.pollErrs <- err:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll starts the streams of new pods and stops the streams of pods that are gone.
+func (f *logsFanIn) poll(ctx context.Context) error {
+	seen := map[string]bool{}
+	for pod, err := range All(ctx, f.c, f.appID, drycc.DefaultPageSize) {
+		if err != nil {
+			return err
+		}
+		if f.opts.Ptype != "" && pod.Type != f.opts.Ptype {
+			continue
+		}
+		seen[pod.Name] = true
+		if _, ok := f.pods[pod.Name]; ok {
+			continue
+		}
+		podCtx, cancel := context.WithCancel(ctx)
+		f.pods[pod.Name] = cancel
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			f.stream(podCtx, pod.Name)
+		}()
+	}
+	for name, cancel := range f.pods {
+		if !seen[name] {
+			cancel()
+			delete(f.pods, name)
+		}
+	}
+	return nil
+}
+
+// stream sends the lines of a pod, reconnecting when following until ctx is done.
+func (f *logsFanIn) stream(ctx context.Context, pod string) {
+	req := api.PodLogsRequest{Lines: f.opts.Lines, Follow: f.opts.Follow, Container: f.opts.Container}
+	var cursor logsCursor
+	for {
+		err := f.read(ctx, pod, req, &cursor)
+		if !f.opts.Follow {
+			if err != nil && ctx.Err() == nil {
+				f.fail(err)
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.opts.ReconnectDelay):
+		}
+	}
+}
+
+// logsCursor is the position of the last timestamped line sent from a pod, to skip the
+// lines sent again after reconnecting.
+type logsCursor struct {
+	// last is the timestamp of the last line, and count the number of lines sent with it.
+	last  time.Time
+	count int
+}
+
+// read sends the lines of a single connection to the stream of a pod.
+func (f *logsFanIn) read(ctx context.Context, pod string, req api.PodLogsRequest, cursor *logsCursor) error {
+	conn, err := LogsWithContext(ctx, f.c, f.appID, pod, req)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	defer conn.Close()
+
+	// Lines up to the last one of the previous connection are sent again, including the
+	// lines sharing its timestamp.
+	since, skipped := *cursor, 0
+	send := func(text string) bool {
+		line := parseLogLine(text)
+		line.Pod, line.Container = pod, req.Container
+		if ts := line.Timestamp; !ts.IsZero() {
+			if ts.Before(since.last) {
+				return true
+			}
+			if ts.Equal(since.last) && skipped < since.count {
+				skipped++
+				return true
+			}
+			if ts.Equal(cursor.last) {
+				cursor.count++
+			} else {
+				*cursor = logsCursor{last: ts, count: 1}
+			}
+		}
+		select {
+		case f.lines <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// Messages may hold several lines, or part of a line.
+	var partial string
+	for {
+		var msg string
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			if partial != "" {
+				send(partial)
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		lines := strings.Split(partial+msg, "\n")
+		partial = lines[len(lines)-1]
+		for _, text := range lines[:len(lines)-1] {
+			if !send(strings.TrimSuffix(text, "\r")) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// parseLogLine splits the timestamp the controller may prefix a line with from its text.
+func parseLogLine(text string) LogLine {
+	if ts, rest, ok := strings.Cut(text, " "); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return LogLine{Timestamp: t, Text: rest}
+		}
+	}
+	return LogLine{Text: text}
+}
@@ -0,0 +1,201 @@
+package ps
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// logPods are the pods of the app whose logs are streamed.
+var logPods = api.PodsList{{Name: "example-go-web-111", Type: "web"}, {Name: "example-go-worker-222", Type: "worker"}}
+
+func TestStreamLogs(t *testing.T) {
+	t.Parallel()
+
+	server, client := newPodServer(t, logPods, nil)
+	if err := server.Log("example-go", "example-go-web-111", "web 1", "web 2", "web 3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Log("example-go", "example-go-worker-222", "worker 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		opts     LogsOptions
+		expected []string
+	}{
+		{LogsOptions{}, []string{"example-go-web-111: web 1", "example-go-web-111: web 2", "example-go-web-111: web 3", "example-go-worker-222: worker 1"}},
+		{LogsOptions{Ptype: "web", Lines: 2}, []string{"example-go-web-111: web 2", "example-go-web-111: web 3"}},
+		{LogsOptions{Ptype: "cron"}, nil},
+	}
+	for _, test := range tests {
+		var actual []string
+		for line, err := range StreamLogs(context.Background(), client, "example-go", test.opts) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line.Timestamp.IsZero() {
+				t.Errorf("Expected a timestamp for %q", line.Text)
+			}
+			actual = append(actual, line.Pod+": "+line.Text)
+		}
+		// Lines of different pods are interleaved.
+		slices.Sort(actual)
+		if !slices.Equal(actual, test.expected) {
+			t.Errorf("Expected %v, Got %v", test.expected, actual)
+		}
+	}
+
+	for _, err := range StreamLogs(context.Background(), client, "unknown", LogsOptions{}) {
+		if err == nil {
+			t.Error("Expected an error for an unknown app")
+		}
+	}
+}
+
+func TestStreamLogsFollow(t *testing.T) {
+	t.Parallel()
+
+	server, client := newPodServer(t, logPods, nil)
+	if err := server.Log("example-go", "example-go-web-111", "before"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := LogsOptions{Ptype: "web", Follow: true, PollInterval: 10 * time.Millisecond, ReconnectDelay: 10 * time.Millisecond}
+	next, stop := iter.Pull2(StreamLogs(ctx, client, "example-go", opts))
+	defer stop()
+	expect := func(pod, text string) {
+		t.Helper()
+		line, err, ok := next()
+		if !ok || err != nil {
+			t.Fatalf("Expected %s: %s, Got %v", pod, text, err)
+		}
+		if line.Pod != pod || line.Text != text {
+			t.Errorf("Expected %s: %s, Got %s: %s", pod, text, line.Pod, line.Text)
+		}
+	}
+	expect("example-go-web-111", "before")
+
+	if err := server.Log("example-go", "example-go-web-111", "after"); err != nil {
+		t.Fatal(err)
+	}
+	expect("example-go-web-111", "after")
+
+	// A new pod is followed, starting with its past lines.
+	pods := api.PodsList{{Name: "example-go-web-111", Type: "web"}, {Name: "example-go-web-333", Type: "web"}}
+	if err := server.SetPods("example-go", pods); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Log("example-go", "example-go-web-333", "new pod"); err != nil {
+		t.Fatal(err)
+	}
+	expect("example-go-web-333", "new pod")
+
+	// Streams reconnect without repeating lines.
+	server.CloseLogStreams()
+	if err := server.Log("example-go", "example-go-web-111", "reconnected"); err != nil {
+		t.Fatal(err)
+	}
+	expect("example-go-web-111", "reconnected")
+}
+
+func TestStreamLogsReconnect(t *testing.T) {
+	t.Parallel()
+
+	server, client := newPodServer(t, webPod, nil)
+	logged := time.Date(2026, 3, 24, 12, 0, 0, 0, time.UTC)
+	if err := server.LogAt("example-go", "example-go-web-111", logged, "first", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.LogAt("example-go", "example-go-web-111", time.Time{}, "untimed"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := LogsOptions{Follow: true, PollInterval: 10 * time.Millisecond, ReconnectDelay: 10 * time.Millisecond}
+	next, stop := iter.Pull2(StreamLogs(ctx, client, "example-go", opts))
+	defer stop()
+	for _, expected := range []string{"first", "second", "untimed"} {
+		if line, err, _ := next(); err != nil || line.Text != expected {
+			t.Fatalf("Expected %s, Got %s (%v)", expected, line.Text, err)
+		}
+	}
+
+	// A new line sharing the timestamp of the last one is sent once reconnected, after the
+	// past lines without a timestamp, which can't be skipped.
+	server.CloseLogStreams()
+	if err := server.LogAt("example-go", "example-go-web-111", logged, "third"); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"untimed", "third"} {
+		if line, err, _ := next(); err != nil || line.Text != expected {
+			t.Fatalf("Expected %s, Got %s (%v)", expected, line.Text, err)
+		}
+	}
+}
+
+func TestStreamLogsPollError(t *testing.T) {
+	t.Parallel()
+
+	server, client := newPodServer(t, logPods, nil)
+	if err := server.Log("example-go", "example-go-web-111", "before"); err != nil {
+		t.Fatal(err)
+	}
+	// The second listing of the pods fails, as during a rollout of the controller.
+	var listings atomic.Int32
+	client.Use(func(next drycc.Doer) drycc.Doer {
+		return drycc.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/pods/") && listings.Add(1) == 2 {
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody, Header: http.Header{}, Request: req}, nil
+			}
+			return next.Do(req)
+		})
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := LogsOptions{Ptype: "web", Follow: true, PollInterval: 10 * time.Millisecond, ReconnectDelay: 10 * time.Millisecond}
+	next, stop := iter.Pull2(StreamLogs(ctx, client, "example-go", opts))
+	defer stop()
+
+	// The line and the error may arrive in any order.
+	var texts []string
+	failed := false
+	for range 2 {
+		line, err, ok := next()
+		if !ok {
+			t.Fatal("Expected the stream to go on")
+		}
+		if err != nil {
+			failed = true
+		} else {
+			texts = append(texts, line.Text)
+		}
+	}
+	if !failed || !slices.Equal(texts, []string{"before"}) {
+		t.Errorf("Expected the line before and an error, Got %v (error: %t)", texts, failed)
+	}
+
+	// Pods are still followed once listing them works again.
+	pods := api.PodsList{{Name: "example-go-web-111", Type: "web"}, {Name: "example-go-web-333", Type: "web"}}
+	if err := server.SetPods("example-go", pods); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Log("example-go", "example-go-web-333", "new pod"); err != nil {
+		t.Fatal(err)
+	}
+	if line, err, ok := next(); !ok || err != nil || line.Text != "new pod" {
+		t.Errorf("Expected new pod, Got %q (%v)", line.Text, err)
+	}
+}
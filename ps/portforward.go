@@ -0,0 +1,255 @@
+package ps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"golang.org/x/net/websocket"
+)
+
+// The port-forward endpoint relays the connection in binary messages whose first byte is
+// the channel, like the port-forward protocol of Kubernetes.
+const (
+	portData  byte = 0
+	portError byte = 1
+)
+
+// DefaultPortForwardAddress is the local address PortForward listens on, unless changed
+// in PortForwardOptions: a random port of the loopback interface.
+const DefaultPortForwardAddress = "127.0.0.1:0"
+
+// DefaultHalfCloseTimeout is how long PortForward waits for a pod to keep responding to a
+// local client that stopped sending, unless changed in PortForwardOptions.
+const DefaultHalfCloseTimeout = 10 * time.Second
+
+// DialPort connects to a port of a pod through the controller. Failures to reach the port,
+// such as a refused connection, are returned by Read.
+func DialPort(ctx context.Context, c *drycc.Client, appID, podID string, port int) (io.ReadWriteCloser, error) {
+	config, err := c.NewWebsocketConfig(fmt.Sprintf("/v2/apps/%s/pods/%s/portforward/", appID, podID))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = websocket.JSON.Send(conn, api.PodPortForwardRequest{Port: port}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &portConn{conn: conn}, nil
+}
+
+// portConn is a connection to a port of a pod.
+type portConn struct {
+	conn *websocket.Conn
+	// buf holds the data received but not read yet.
+	buf []byte
+}
+
+func (c *portConn) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		var msg []byte
+		if err := websocket.Message.Receive(c.conn, &msg); err != nil {
+			return 0, err
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		switch msg[0] {
+		case portData:
+			c.buf = msg[1:]
+		case portError:
+			return 0, fmt.Errorf("port forward failed: %s", msg[1:])
+		}
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *portConn) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := websocket.Message.Send(c.conn, append([]byte{portData}, p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *portConn) Close() error {
+	return c.conn.Close()
+}
+
+// PortForwardOptions configures PortForward.
+type PortForwardOptions struct {
+	// Address is the local address to listen on. It defaults to
+	// DefaultPortForwardAddress.
+	Address string
+	// OnError, if set, is called with the failures of forwarded connections, such as the
+	// pod refusing them. It may be called concurrently.
+	OnError func(error)
+	// HalfCloseTimeout is how long the response of a pod is still forwarded without any
+	// data once the local client stopped sending, as the pod can't be told. It defaults
+	// to DefaultHalfCloseTimeout.
+	HalfCloseTimeout time.Duration
+}
+
+// PortForwarder forwards the connections to a local address to a port of a pod, started
+// with PortForward.
+type PortForwarder struct {
+	listener net.Listener
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+}
+
+// PortForward listens on a local address and forwards every connection to a port of a pod
+// through the controller, until ctx is done or the forwarder is closed. Connections are
+// forwarded concurrently, each over its own websocket. They end when the pod closes them or
+// either side fails, while a local client closing its side still receives the rest of the
+// response until the pod stays silent for opts.HalfCloseTimeout.
+func PortForward(ctx context.Context, c *drycc.Client, appID, podID string, port int, opts PortForwardOptions) (*PortForwarder, error) {
+	if opts.Address == "" {
+		opts.Address = DefaultPortForwardAddress
+	}
+	if opts.HalfCloseTimeout <= 0 {
+		opts.HalfCloseTimeout = DefaultHalfCloseTimeout
+	}
+	l, err := (&net.ListenConfig{}).Listen(ctx, "tcp", opts.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	f := &PortForwarder{listener: l, cancel: cancel, done: make(chan struct{})}
+	go f.serve(ctx, func(ctx context.Context) (io.ReadWriteCloser, error) {
+		return DialPort(ctx, c, appID, podID, port)
+	}, opts)
+	return f, nil
+}
+
+// Addr returns the local address connections are forwarded from.
+func (f *PortForwarder) Addr() net.Addr {
+	return f.listener.Addr()
+}
+
+// Wait waits for the forwarder to stop and its connections to be closed. It returns the
+// error that stopped it, or nil once ctx is done or Close is called.
+func (f *PortForwarder) Wait() error {
+	<-f.done
+	return f.err
+}
+
+// Close stops listening, closes the forwarded connections and waits for them.
+func (f *PortForwarder) Close() error {
+	f.cancel()
+	return f.Wait()
+}
+
+// serve accepts the connections until ctx is done or the listener fails.
+func (f *PortForwarder) serve(ctx context.Context, dial func(context.Context) (io.ReadWriteCloser, error), opts PortForwardOptions) {
+	defer close(f.done)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	stop := context.AfterFunc(ctx, func() { f.listener.Close() })
+	defer stop()
+
+	for {
+		local, err := f.listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				f.err = err
+				f.listener.Close()
+			}
+			// Closes the connections being forwarded.
+			f.cancel()
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := forward(ctx, local, dial, opts.HalfCloseTimeout); err != nil && ctx.Err() == nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+		}()
+	}
+}
+
+// forward copies a local connection to a remote one and back. The local client may stop
+// sending while still waiting for a response, so the connection only ends once the remote
+// side ends or stays silent for halfClose, either side fails, or ctx is done.
+func forward(ctx context.Context, local net.Conn, dial func(context.Context) (io.ReadWriteCloser, error), halfClose time.Duration) error {
+	defer local.Close()
+	remote, err := dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+	closeBoth := func() {
+		local.Close()
+		remote.Close()
+	}
+	stop := context.AfterFunc(ctx, closeBoth)
+	defer stop()
+
+	sent, received := make(chan error, 1), make(chan error, 1)
+	active := make(chan struct{}, 1)
+	go func() {
+		_, err := io.Copy(remote, local)
+		sent <- err
+	}()
+	go func() {
+		_, err := io.Copy(activeWriter{local, active}, remote)
+		received <- err
+	}()
+	select {
+	case err = <-received:
+		closeBoth()
+		<-sent
+		return err
+	case err = <-sent:
+	}
+	if err != nil {
+		closeBoth()
+		<-received
+		return err
+	}
+
+	// The local client closed the connection, or only its sending side.
+	timer := time.NewTimer(halfClose)
+	defer timer.Stop()
+	for {
+		select {
+		case err = <-received:
+			closeBoth()
+			return err
+		case <-active:
+			timer.Reset(halfClose)
+		case <-timer.C:
+			closeBoth()
+			<-received
+			return nil
+		}
+	}
+}
+
+// activeWriter signals active whenever data is written to w.
+type activeWriter struct {
+	w      io.Writer
+	active chan struct{}
+}
+
+func (a activeWriter) Write(p []byte) (int, error) {
+	select {
+	case a.active <- struct{}{}:
+	default:
+	}
+	return a.w.Write(p)
+}
@@ -0,0 +1,198 @@
+package ps
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drycc/controller-sdk-go/drycctest"
+)
+
+// echoPort connects to a pod listening on port 7, which echoes what it receives, on port 9,
+// which discards it, and on port 80, which answers a line once it is received and closes
+// the connection.
+func echoPort(appID, podID string, port int) (net.Conn, error) {
+	conn, pod := net.Pipe()
+	switch port {
+	case 7:
+		go func() {
+			defer pod.Close()
+			io.Copy(pod, pod)
+		}()
+	case 9:
+		go func() {
+			defer pod.Close()
+			io.Copy(io.Discard, pod)
+		}()
+	case 80:
+		go func() {
+			defer pod.Close()
+			line, err := bufio.NewReader(pod).ReadString('\n')
+			if err != nil {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+			fmt.Fprintf(pod, "reply to %s", line)
+		}()
+	default:
+		conn.Close()
+		pod.Close()
+		return nil, fmt.Errorf("error forwarding port %d to pod %s: connection refused", port, podID)
+	}
+	return conn, nil
+}
+
+func TestPortForward(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetPortForward(echoPort) })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f, err := PortForward(ctx, client, "example-go", "example-go-web-111", 7, PortForwardOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Connections are forwarded concurrently.
+	conns := make([]net.Conn, 3)
+	var wg sync.WaitGroup
+	for i := range conns {
+		conn, err := net.Dial("tcp", f.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns[i] = conn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out := bufio.NewReader(conn)
+			for j := range 3 {
+				expected := fmt.Sprintf("connection %d line %d\n", i, j)
+				fmt.Fprint(conn, expected)
+				if line, err := out.ReadString('\n'); err != nil || line != expected {
+					t.Errorf("Expected %q, Got %q (%v)", expected, line, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Canceling the context closes the connections and stops listening.
+	cancel()
+	if err := f.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	for _, conn := range conns {
+		if n, err := conn.Read(make([]byte, 1)); err == nil {
+			t.Errorf("Expected a closed connection, Got %d bytes", n)
+		}
+		conn.Close()
+	}
+	if conn, err := net.Dial("tcp", f.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("Expected the forwarder to stop listening")
+	}
+}
+
+func TestPortForwardHalfClose(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetPortForward(echoPort) })
+	f, err := PortForward(context.Background(), client, "example-go", "example-go-web-111", 80, PortForwardOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	conn, err := net.Dial("tcp", f.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	fmt.Fprint(conn, "request\n")
+	// The response still arrives once the client stops sending.
+	if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(conn); err != nil || string(data) != "reply to request\n" {
+		t.Errorf("Expected %q, Got %q (%v)", "reply to request\n", data, err)
+	}
+}
+
+func TestPortForwardHalfCloseIdle(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetPortForward(echoPort) })
+	f, err := PortForward(context.Background(), client, "example-go", "example-go-web-111", 9,
+		PortForwardOptions{HalfCloseTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	conn, err := net.Dial("tcp", f.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	fmt.Fprint(conn, "request\n")
+	// A pod that never responds doesn't keep the connection open.
+	if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(conn); err != nil || len(data) != 0 {
+		t.Errorf("Expected a closed connection, Got %q (%v)", data, err)
+	}
+}
+
+func TestPortForwardRefused(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetPortForward(echoPort) })
+	errs := make(chan error, 1)
+	f, err := PortForward(context.Background(), client, "example-go", "example-go-web-111", 5432,
+		PortForwardOptions{OnError: func(err error) { errs <- err }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	conn, err := net.Dial("tcp", f.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if data, err := io.ReadAll(conn); err != nil || len(data) != 0 {
+		t.Errorf("Expected a closed connection, Got %q (%v)", data, err)
+	}
+	if err := <-errs; err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected a refused connection, Got %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Errorf("Expected no error, Got %v", err)
+	}
+}
+
+func TestDialPort(t *testing.T) {
+	t.Parallel()
+
+	_, client := newPodServer(t, webPod, func(s *drycctest.Server) { s.SetPortForward(echoPort) })
+	conn, err := DialPort(context.Background(), client, "example-go", "missing", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := io.ReadAll(conn); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a missing pod, Got %v", err)
+	}
+}
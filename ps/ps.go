@@ -2,10 +2,9 @@
 package ps
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
-	"net/url"
+	"iter"
 	"sort"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -15,69 +14,59 @@ import (
 
 // List lists an app's processes.
 func List(c *drycc.Client, appID string, results int) (api.PodsList, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/pods/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Pods{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var procs []api.Pods
-	if err := json.Unmarshal([]byte(body), &procs); err != nil {
-		return []api.Pods{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.PodsList, int, error) {
+	return drycc.List[api.Pods](ctx, c, fmt.Sprintf("/v2/apps/%s/pods/", appID), results)
+}
 
-	return procs, count, reqErr
+// All returns an iterator over every process of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Pods, error] {
+	return drycc.All[api.Pods](ctx, c, fmt.Sprintf("/v2/apps/%s/pods/", appID), pageSize)
 }
 
 // Exec a command in a container.
 func Exec(c *drycc.Client, appID, podID string, command api.Command) (*websocket.Conn, error) {
-	scheme := "ws"
-	if c.ControllerURL.Scheme == "https" {
-		scheme = "wss"
-	}
-	path := fmt.Sprintf("v2/apps/%s/pods/%s/exec/", appID, podID)
-	u := url.URL{Scheme: scheme, Host: c.ControllerURL.Host, Path: path}
-	config, err := websocket.NewConfig(u.String(), c.ControllerURL.String())
+	return ExecWithContext(context.Background(), c, appID, podID, command)
+}
+
+// ExecWithContext is like [Exec] but carries ctx.
+func ExecWithContext(ctx context.Context, c *drycc.Client, appID, podID string, command api.Command) (*websocket.Conn, error) {
+	config, err := c.NewWebsocketConfig(fmt.Sprintf("/v2/apps/%s/pods/%s/exec/", appID, podID))
 	if err != nil {
 		return nil, err
 	}
-	config.Header = http.Header{
-		"User-Agent":          {c.UserAgent},
-		"Authorization":       {"token " + c.Token},
-		"X-Drycc-Service-Key": {c.ServiceKey},
-	}
-	conn, err := websocket.DialConfig(config)
+	conn, err := config.DialContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	websocket.JSON.Send(conn, command)
+	if err = websocket.JSON.Send(conn, command); err != nil {
+		conn.Close()
+		return nil, err
+	}
 	return conn, nil
 }
 
 // Logs retrieves logs from an pod. The number of log lines fetched can be set by the lines
 func Logs(c *drycc.Client, appID, podID string, request api.PodLogsRequest) (*websocket.Conn, error) {
-	scheme := "ws"
-	if c.ControllerURL.Scheme == "https" {
-		scheme = "wss"
-	}
-	path := fmt.Sprintf("v2/apps/%s/pods/%s/logs/", appID, podID)
-	endpoint := url.URL{Scheme: scheme, Host: c.ControllerURL.Host, Path: path}
+	return LogsWithContext(context.Background(), c, appID, podID, request)
+}
 
-	config, err := websocket.NewConfig(endpoint.String(), c.ControllerURL.String())
+// LogsWithContext is like [Logs] but carries ctx.
+func LogsWithContext(ctx context.Context, c *drycc.Client, appID, podID string, request api.PodLogsRequest) (*websocket.Conn, error) {
+	config, err := c.NewWebsocketConfig(fmt.Sprintf("/v2/apps/%s/pods/%s/logs/", appID, podID))
 	if err != nil {
 		return nil, err
 	}
-	config.Header = http.Header{
-		"User-Agent":          {c.UserAgent},
-		"Authorization":       {"token " + c.Token},
-		"X-Drycc-Service-Key": {c.ServiceKey},
-	}
-	conn, err := websocket.DialConfig(config)
+	conn, err := config.DialContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	err = websocket.JSON.Send(conn, request)
-	if err != nil {
+	if err = websocket.JSON.Send(conn, request); err != nil {
+		conn.Close()
 		return nil, err
 	}
 	return conn, nil
@@ -85,35 +74,23 @@ func Logs(c *drycc.Client, appID, podID string, request api.PodLogsRequest) (*we
 
 // Describe pod state
 func Describe(c *drycc.Client, appID string, podID string, results int) (api.PodState, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/pods/%s/describe/", appID, podID)
-
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.PodState{}, -1, reqErr
-	}
+	return DescribeWithContext(context.Background(), c, appID, podID, results)
+}
 
-	var podState api.PodState
-	if err := json.Unmarshal([]byte(body), &podState); err != nil {
-		return api.PodState{}, -1, err
-	}
-	return podState, count, reqErr
+// DescribeWithContext is like [Describe] but carries ctx.
+func DescribeWithContext(ctx context.Context, c *drycc.Client, appID string, podID string, results int) (api.PodState, int, error) {
+	return drycc.List[api.ContainerState](ctx, c, fmt.Sprintf("/v2/apps/%s/pods/%s/describe/", appID, podID), results)
 }
 
 // Delete deletes a pod from an app.
 func Delete(c *drycc.Client, appID string, podIDs string) error {
-	u := fmt.Sprintf("/v2/apps/%s/pods/", appID)
+	return DeleteWithContext(context.Background(), c, appID, podIDs)
+}
 
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, podIDs string) error {
 	req := api.PodIDs{PodIDs: podIDs}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-	res, err := c.Request("DELETE", u, body)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/pods/", appID), req)
 }
 
 // ByType organizes processes of an app by process type.
@@ -1,6 +1,7 @@
 package ps
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,8 @@ import (
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/drycctest"
 	"golang.org/x/net/websocket"
 )
 
@@ -102,6 +105,33 @@ func (fakeHTTPServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	res.Write(nil)
 }
 
+// webPod is the pod of the app example-go commands are run in.
+var webPod = api.PodsList{{Name: "example-go-web-111", Type: "web"}}
+
+// newPodServer starts a fake controller with the app example-go running pods, and returns
+// it with a client. configure, if not nil, sets up the server before the app is created.
+func newPodServer(t *testing.T, pods api.PodsList, configure func(*drycctest.Server)) (*drycctest.Server, *drycc.Client) {
+	t.Helper()
+
+	server := drycctest.NewServer()
+	t.Cleanup(server.Close)
+	if configure != nil {
+		configure(server)
+	}
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apps.New(client, "example-go", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.SetPods("example-go", pods); err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
 func TestProcessesList(t *testing.T) {
 	t.Parallel()
 
@@ -332,3 +362,25 @@ func TestPodsDelete(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestExecWithContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		io.Copy(conn, conn)
+	}))
+	defer server.Close()
+
+	drycc, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = ExecWithContext(ctx, drycc, "example-go", "example-go-web-111", api.Command{Command: []string{"ls"}})
+	if err == nil {
+		t.Error("Expected an error dialing with a canceled context")
+	}
+}
@@ -0,0 +1,185 @@
+package ps
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/events"
+)
+
+const (
+	// DefaultWaitPollInterval is how often pods are listed while waiting, unless changed in
+	// WaitOptions.
+	DefaultWaitPollInterval = 2 * time.Second
+	// DefaultMaxRestarts is how many times a pod that isn't ready can restart before it is
+	// considered crash looping, unless changed in WaitOptions.
+	DefaultMaxRestarts = 3
+)
+
+// crashLoopEvents is the number of events of a pod reported in a CrashLoopError.
+const crashLoopEvents = 10
+
+// WaitOptions selects what WaitForPods and pts.WaitReady wait for.
+type WaitOptions struct {
+	// Ptype limits the pods to a process type. If empty, every pod of the app must be
+	// ready.
+	Ptype string
+	// Release, if set, waits for the pods to run this release, such as "v3", so that the
+	// pods of a previous release being replaced aren't counted.
+	Release string
+	// Replicas, if set, is the number of pods to wait for, 0 waiting for the pods to be
+	// removed after scaling down. Otherwise at least one pod must be ready.
+	Replicas *int
+	// PollInterval is how often the state is listed. It defaults to
+	// DefaultWaitPollInterval.
+	PollInterval time.Duration
+	// MaxRestarts is how many times a pod that isn't ready can restart before the wait
+	// fails with a CrashLoopError. It defaults to DefaultMaxRestarts.
+	MaxRestarts int
+}
+
+// WithDefaults returns the options with their unset fields set to the defaults, for
+// helpers waiting with them outside of this package.
+func (o WaitOptions) WithDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultWaitPollInterval
+	}
+	if o.MaxRestarts <= 0 {
+		o.MaxRestarts = DefaultMaxRestarts
+	}
+	return o
+}
+
+// CrashLoopError is returned while waiting for pods when one of them keeps restarting
+// without becoming ready.
+type CrashLoopError struct {
+	Pod string
+	// Containers are the containers of the pod that restarted too many times.
+	Containers api.PodState
+	// Events are the last events of the pod.
+	Events api.AppEvents
+}
+
+func (e *CrashLoopError) Error() string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "pod %s is crash looping:", e.Pod)
+	for _, c := range e.Containers {
+		fmt.Fprintf(&msg, " container %s restarted %d times", c.Container, c.RestartCount)
+		if reason := containerReason(c); reason != "" {
+			fmt.Fprintf(&msg, " (%s)", reason)
+		}
+	}
+	for _, event := range e.Events {
+		fmt.Fprintf(&msg, "\n%s %s: %s", event.Created, event.Reason, event.Message)
+	}
+	return msg.String()
+}
+
+// containerReason returns why a container isn't running, such as CrashLoopBackOff.
+func containerReason(c api.ContainerState) string {
+	if c.Reason != "" {
+		return c.Reason
+	}
+	for _, state := range c.State {
+		if reason, ok := state["reason"].(string); ok {
+			return reason
+		}
+	}
+	return ""
+}
+
+// ParseReady parses the ready count of a pod or process type, such as "2/3", into the
+// number of ready and desired containers or replicas.
+func ParseReady(ready string) (int, int, error) {
+	r, d, ok := strings.Cut(ready, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid ready count %q", ready)
+	}
+	readyCount, err := strconv.Atoi(strings.TrimSpace(r))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ready count %q", ready)
+	}
+	desired, err := strconv.Atoi(strings.TrimSpace(d))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ready count %q", ready)
+	}
+	return readyCount, desired, nil
+}
+
+// podReady returns whether every container of a pod is ready.
+func podReady(pod api.Pods) bool {
+	ready, desired, err := ParseReady(pod.Ready)
+	return err == nil && desired > 0 && ready == desired
+}
+
+// WaitForPods polls the pods of an app until they are ready, and returns them. It fails
+// with a CrashLoopError if a pod keeps restarting, and with the error of ctx, along with
+// the pods last listed, once ctx is done.
+func WaitForPods(ctx context.Context, c *drycc.Client, appID string, opts WaitOptions) (api.PodsList, error) {
+	opts = opts.WithDefaults()
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		var pods api.PodsList
+		for pod, err := range All(ctx, c, appID, drycc.DefaultPageSize) {
+			if err != nil {
+				return nil, err
+			}
+			if opts.Ptype == "" || pod.Type == opts.Ptype {
+				pods = append(pods, pod)
+			}
+		}
+
+		ready := 0
+		for _, pod := range pods {
+			if podReady(pod) && (opts.Release == "" || pod.Release == opts.Release) {
+				ready++
+			}
+		}
+		if ready == len(pods) && (opts.Replicas != nil && ready == *opts.Replicas || opts.Replicas == nil && ready > 0) {
+			return pods, nil
+		}
+		if err := CheckCrashLoop(ctx, c, appID, pods, opts.MaxRestarts); err != nil {
+			return pods, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return pods, fmt.Errorf("%w: %d of %d pods ready", ctx.Err(), ready, len(pods))
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckCrashLoop returns a CrashLoopError for the first pod that isn't ready and whose
+// containers restarted at least maxRestarts times, describing it and listing its events.
+func CheckCrashLoop(ctx context.Context, c *drycc.Client, appID string, pods api.PodsList, maxRestarts int) error {
+	for _, pod := range pods {
+		if podReady(pod) || pod.Restarts < maxRestarts {
+			continue
+		}
+		state, _, err := DescribeWithContext(ctx, c, appID, pod.Name, drycc.DefaultPageSize)
+		if err != nil {
+			return err
+		}
+		crashErr := &CrashLoopError{Pod: pod.Name}
+		for _, container := range state {
+			if !container.Ready && container.RestartCount >= maxRestarts {
+				crashErr.Containers = append(crashErr.Containers, container)
+			}
+		}
+		if len(crashErr.Containers) == 0 {
+			continue
+		}
+		if crashErr.Events, _, err = events.ListPodEventsWithContext(ctx, c, appID, pod.Name, crashLoopEvents); err != nil {
+			return err
+		}
+		return crashErr
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package ps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+func TestParseReady(t *testing.T) {
+	t.Parallel()
+
+	ready, desired, err := ParseReady("2/3")
+	if err != nil || ready != 2 || desired != 3 {
+		t.Errorf("Expected 2 and 3, Got %d and %d (%v)", ready, desired, err)
+	}
+	for _, invalid := range []string{"", "2", "a/3", "2/b"} {
+		if _, _, err := ParseReady(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestWaitOptionsWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	opts := WaitOptions{Ptype: "web", MaxRestarts: -1}.WithDefaults()
+	expected := WaitOptions{Ptype: "web", PollInterval: DefaultWaitPollInterval, MaxRestarts: DefaultMaxRestarts}
+	if opts != expected {
+		t.Errorf("Expected %v, Got %v", expected, opts)
+	}
+	opts = WaitOptions{PollInterval: time.Second, MaxRestarts: 1}
+	if opts.WithDefaults() != opts {
+		t.Errorf("Expected %v, Got %v", opts, opts.WithDefaults())
+	}
+}
+
+func TestWaitForPods(t *testing.T) {
+	t.Parallel()
+
+	pods := api.PodsList{
+		{Name: "example-go-web-111", Type: "web", Release: "v2", Ready: "1/1"},
+		{Name: "example-go-web-222", Type: "web", Release: "v1", Ready: "1/1"},
+		{Name: "example-go-worker-333", Type: "worker", Release: "v2", Ready: "0/1"},
+	}
+	server, client := newPodServer(t, pods, nil)
+	opts := WaitOptions{Ptype: "web", PollInterval: 10 * time.Millisecond}
+
+	actual, err := WaitForPods(context.Background(), client, "example-go", opts)
+	if err != nil || len(actual) != 2 {
+		t.Errorf("Expected the 2 web pods, Got %v (%v)", actual, err)
+	}
+
+	// The pod of the previous release is still being replaced.
+	opts.Release = "v2"
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := WaitForPods(ctx, client, "example-go", opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		pods[1] = api.Pods{Name: "example-go-web-444", Type: "web", Release: "v2", Ready: "1/1"}
+		server.SetPods("example-go", pods)
+	}()
+	replicas := 2
+	opts.Replicas = &replicas
+	if actual, err := WaitForPods(context.Background(), client, "example-go", opts); err != nil || len(actual) != 2 {
+		t.Errorf("Expected the 2 web pods, Got %v (%v)", actual, err)
+	}
+
+	// Scaling down to 0 waits for the pods to be removed.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.SetPods("example-go", pods[2:])
+	}()
+	replicas = 0
+	opts.Release = ""
+	if actual, err := WaitForPods(context.Background(), client, "example-go", opts); err != nil || len(actual) != 0 {
+		t.Errorf("Expected no web pods, Got %v (%v)", actual, err)
+	}
+}
+
+func TestWaitForPodsCrashLoop(t *testing.T) {
+	t.Parallel()
+
+	server, client := newPodServer(t, api.PodsList{
+		{Name: "example-go-web-111", Type: "web", Ready: "1/1", Restarts: 5},
+		{Name: "example-go-web-222", Type: "web", Ready: "0/1", Restarts: 4},
+	}, nil)
+	event := api.AppEvent{Reason: "BackOff", Message: "Back-off restarting failed container"}
+	if err := server.AddEvent("example-go", "example-go-web-222", event); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := WaitForPods(context.Background(), client, "example-go", WaitOptions{PollInterval: 10 * time.Millisecond})
+	crashErr := &CrashLoopError{}
+	if !errors.As(err, &crashErr) {
+		t.Fatalf("Expected a CrashLoopError, Got %v", err)
+	}
+	if crashErr.Pod != "example-go-web-222" || len(crashErr.Containers) != 1 || crashErr.Containers[0].RestartCount != 4 {
+		t.Errorf("Expected example-go-web-222 restarting 4 times, Got %+v", crashErr)
+	}
+	if len(crashErr.Events) != 1 || crashErr.Events[0].Reason != "BackOff" {
+		t.Errorf("Expected the BackOff event, Got %v", crashErr.Events)
+	}
+	for _, expected := range []string{"restarted 4 times (CrashLoopBackOff)", "BackOff: Back-off restarting failed container"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %q", expected, err)
+		}
+	}
+
+	// Restarts below the limit are waited for.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := WaitForPods(ctx, client, "example-go", WaitOptions{PollInterval: 10 * time.Millisecond, MaxRestarts: 5}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -2,94 +2,74 @@
 package pts
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 	"sort"
+	"time"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/ps"
 )
 
 // List lists an app's processes.
 func List(c *drycc.Client, appID string, results int) (api.Ptypes, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/ptypes/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Ptype{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var ptypes []api.Ptype
-	if err := json.Unmarshal([]byte(body), &ptypes); err != nil {
-		return []api.Ptype{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Ptypes, int, error) {
+	return drycc.List[api.Ptype](ctx, c, fmt.Sprintf("/v2/apps/%s/ptypes/", appID), results)
+}
 
-	return ptypes, count, reqErr
+// All returns an iterator over every process type of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Ptype, error] {
+	return drycc.All[api.Ptype](ctx, c, fmt.Sprintf("/v2/apps/%s/ptypes/", appID), pageSize)
 }
 
 // Describe Ptype state
 func Describe(c *drycc.Client, appID string, ptype string, results int) (api.PtypeStates, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/ptypes/%s-%s/describe/", appID, appID, ptype)
-
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.PtypeStates{}, -1, reqErr
-	}
+	return DescribeWithContext(context.Background(), c, appID, ptype, results)
+}
 
-	var PtypeStates api.PtypeStates
-	if err := json.Unmarshal([]byte(body), &PtypeStates); err != nil {
-		return api.PtypeStates{}, -1, err
-	}
-	return PtypeStates, count, reqErr
+// DescribeWithContext is like [Describe] but carries ctx.
+func DescribeWithContext(ctx context.Context, c *drycc.Client, appID string, ptype string, results int) (api.PtypeStates, int, error) {
+	return drycc.List[api.PtypeState](ctx, c, fmt.Sprintf("/v2/apps/%s/ptypes/%s-%s/describe/", appID, appID, ptype), results)
 }
 
 // Scale increases or decreases an app's processes. The processes are specified in the target argument,
 // a key-value map, where the key is the process name and the value is the number of replicas
 func Scale(c *drycc.Client, appID string, targets map[string]int) error {
-	u := fmt.Sprintf("/v2/apps/%s/ptypes/scale/", appID)
-
-	body, err := json.Marshal(targets)
-	if err != nil {
-		return err
-	}
+	return ScaleWithContext(context.Background(), c, appID, targets)
+}
 
-	res, err := c.Request("POST", u, body)
-	if err != nil && !drycc.IsErrAPIMismatch(err) {
-		return err
-	}
-	defer res.Body.Close()
-	return err
+// ScaleWithContext is like [Scale] but carries ctx.
+func ScaleWithContext(ctx context.Context, c *drycc.Client, appID string, targets map[string]int) error {
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/ptypes/scale/", appID), targets)
 }
 
 // Restart restarts an app's processes. To restart all app processes, pass empty strings for
 // procType and name. To restart an specific process, pass an procType by leave name empty.
 // To restart a specific instance, pass a procType and a name.
 func Restart(c *drycc.Client, appID string, targets map[string]string) error {
-	u := fmt.Sprintf("/v2/apps/%s/ptypes/restart/", appID)
-	body, err := json.Marshal(targets)
-	if err != nil {
-		return err
-	}
-	res, err := c.Request("POST", u, body)
-	if err != nil && !drycc.IsErrAPIMismatch(err) {
-		return err
-	}
-	defer res.Body.Close()
-	return err
+	return RestartWithContext(context.Background(), c, appID, targets)
+}
+
+// RestartWithContext is like [Restart] but carries ctx.
+func RestartWithContext(ctx context.Context, c *drycc.Client, appID string, targets map[string]string) error {
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/ptypes/restart/", appID), targets)
 }
 
 // Clean clean an app's processes.
 func Clean(c *drycc.Client, appID string, targets map[string]string) error {
-	u := fmt.Sprintf("/v2/apps/%s/ptypes/clean/", appID)
-	body, err := json.Marshal(targets)
-	if err != nil {
-		return err
-	}
-	res, err := c.Request("POST", u, body)
-	if err != nil && !drycc.IsErrAPIMismatch(err) {
-		return err
-	}
-	defer res.Body.Close()
-	return err
+	return CleanWithContext(context.Background(), c, appID, targets)
+}
+
+// CleanWithContext is like [Clean] but carries ctx.
+func CleanWithContext(ctx context.Context, c *drycc.Client, appID string, targets map[string]string) error {
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/ptypes/clean/", appID), targets)
 }
 
 // ByType organizes process types of an app by process type.
@@ -99,3 +79,71 @@ func ByType(ptypes api.Ptypes) api.Ptypes {
 
 	return ptypes
 }
+
+// WaitReady polls a process type of an app until its replicas are ready, up to date and
+// available, and returns it. opts.Ptype is ignored, while opts.Replicas and opts.Release
+// also wait for the process type to be scaled or deployed. Scaling down to 0 replicas is
+// waited for until no pod of the process type remains. It fails with a ps.CrashLoopError
+// if a pod of the process type keeps restarting, and with the error of ctx once ctx is
+// done. A process type that isn't listed yet is waited for.
+func WaitReady(ctx context.Context, c *drycc.Client, appID, ptype string, opts ps.WaitOptions) (api.Ptype, error) {
+	opts = opts.WithDefaults()
+	scaledDown := opts.Replicas != nil && *opts.Replicas == 0
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		var state api.Ptype
+		found := false
+		for p, err := range All(ctx, c, appID, drycc.DefaultPageSize) {
+			if err != nil {
+				return api.Ptype{}, err
+			}
+			// The controller names process types after their deployment, <app>-<ptype>, as
+			// the describe endpoint does.
+			if p.Name == ptype || p.Name == appID+"-"+ptype {
+				state, found = p, true
+			}
+		}
+		if found && ptypeReady(state, opts) && !scaledDown {
+			return state, nil
+		}
+
+		var pods api.PodsList
+		for pod, err := range ps.All(ctx, c, appID, drycc.DefaultPageSize) {
+			if err != nil {
+				return state, err
+			}
+			if pod.Type == ptype {
+				pods = append(pods, pod)
+			}
+		}
+		if scaledDown && len(pods) == 0 && (!found || ptypeReady(state, opts)) {
+			return state, nil
+		}
+		if err := ps.CheckCrashLoop(ctx, c, appID, pods, opts.MaxRestarts); err != nil {
+			return state, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if scaledDown {
+				return state, fmt.Errorf("%w: %d pods left", ctx.Err(), len(pods))
+			}
+			if !found {
+				return state, fmt.Errorf("%w: process type %s not found", ctx.Err(), ptype)
+			}
+			return state, fmt.Errorf("%w: %s ready", ctx.Err(), state.Ready)
+		case <-ticker.C:
+		}
+	}
+}
+
+// ptypeReady returns whether every replica of a process type is ready, up to date and
+// available.
+func ptypeReady(p api.Ptype, opts ps.WaitOptions) bool {
+	ready, desired, err := ps.ParseReady(p.Ready)
+	if err != nil || ready != desired || p.UpToDate != desired || p.AvailableReplicas != desired {
+		return false
+	}
+	return (opts.Replicas == nil || desired == *opts.Replicas) && (opts.Release == "" || p.Release == opts.Release)
+}
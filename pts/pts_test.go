@@ -1,15 +1,21 @@
 package pts
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/drycctest"
+	"github.com/drycc/controller-sdk-go/ps"
 )
 
 const ptypesFixture string = `
@@ -285,3 +291,88 @@ func TestAppsClean(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestWaitReady(t *testing.T) {
+	t.Parallel()
+
+	server := drycctest.NewServer()
+	defer server.Close()
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apps.New(client, "example-go", "test"); err != nil {
+		t.Fatal(err)
+	}
+	rolling := api.Ptypes{{Name: "web", Release: "v2", Ready: "1/3", UpToDate: 1, AvailableReplicas: 1}}
+	if err := server.SetPtypes("example-go", rolling); err != nil {
+		t.Fatal(err)
+	}
+	opts := ps.WaitOptions{Release: "v2", PollInterval: 10 * time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := WaitReady(ctx, client, "example-go", "web", opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.SetPtypes("example-go", api.Ptypes{{Name: "web", Release: "v2", Ready: "3/3", UpToDate: 3, AvailableReplicas: 3}})
+	}()
+	ptype, err := WaitReady(context.Background(), client, "example-go", "web", opts)
+	if err != nil || ptype.Ready != "3/3" {
+		t.Errorf("Expected 3/3 ready, Got %v (%v)", ptype, err)
+	}
+
+	// Crash looping pods fail the wait.
+	if err := server.SetPtypes("example-go", rolling); err != nil {
+		t.Fatal(err)
+	}
+	crashing := api.PodsList{{Name: "example-go-web-111", Type: "web", Release: "v2", Ready: "0/1", Restarts: 3}}
+	if err := server.SetPods("example-go", crashing); err != nil {
+		t.Fatal(err)
+	}
+	crashErr := &ps.CrashLoopError{}
+	if _, err := WaitReady(context.Background(), client, "example-go", "web", opts); !errors.As(err, &crashErr) || crashErr.Pod != "example-go-web-111" {
+		t.Errorf("Expected example-go-web-111 crash looping, Got %v", err)
+	}
+
+	// Scaling down to 0 waits for the pods to be removed.
+	if err := server.SetPtypes("example-go", api.Ptypes{{Name: "web", Release: "v2", Ready: "0/0"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.SetPods("example-go", api.PodsList{{Name: "example-go-web-111", Type: "web", Release: "v2", State: "terminating", Ready: "1/1"}}); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.SetPods("example-go", nil)
+	}()
+	replicas := 0
+	opts.Replicas = &replicas
+	start := time.Now()
+	if ptype, err := WaitReady(context.Background(), client, "example-go", "web", opts); err != nil || ptype.Ready != "0/0" {
+		t.Errorf("Expected 0/0 ready, Got %v (%v)", ptype, err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected to wait for the pods to be removed, Got %v", elapsed)
+	}
+}
+
+func TestWaitReadyDeploymentName(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeHTTPServer{})
+	defer server.Close()
+	client, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The controller lists the process type web as example-go-web.
+	ptype, err := WaitReady(context.Background(), client, "example-go", "web", ps.WaitOptions{})
+	if err != nil || ptype.Name != "example-go-web" {
+		t.Errorf("Expected example-go-web, Got %v (%v)", ptype, err)
+	}
+}
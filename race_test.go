@@ -0,0 +1,96 @@
+package drycc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/apps"
+	"github.com/drycc/controller-sdk-go/auth"
+	"github.com/drycc/controller-sdk-go/ps"
+)
+
+func raceServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/apps/", func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`{"count": 1, "next": null, "previous": null, "results": [{"id": "example-go"}]}`))
+	})
+	mux.HandleFunc("/v2/apps/example-go/pods/", func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`{"count": 1, "next": null, "previous": null, "results": [{"name": "example-go-web-1"}]}`))
+	})
+	mux.HandleFunc("/v2/auth/login/", func(res http.ResponseWriter, req *http.Request) {
+		http.Redirect(res, req, "/oauth/authorize/", http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/authorize/", func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte("{}"))
+	})
+	mux.HandleFunc("/healthz", func(res http.ResponseWriter, req *http.Request) {})
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Add("DRYCC_API_VERSION", drycc.APIVersion)
+		res.Header().Add("DRYCC_PLATFORM_VERSION", "v9000")
+		mux.ServeHTTP(res, req)
+	})
+}
+
+// TestConcurrentRequests shares one client across goroutines calling several packages.
+// Run with -race to detect unsynchronized client state.
+func TestConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(raceServer())
+	defer server.Close()
+
+	client, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if _, _, err := apps.List(client, "test", 100); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() {
+			if _, _, err := ps.List(client, "example-go", 100); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() {
+			if _, err := drycc.ListAll[map[string]any](t.Context(), client, "/v2/apps/", 10); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() {
+			location, err := auth.Login(client, "", "")
+			if err != nil {
+				t.Error(err)
+			}
+			if location != "/oauth/authorize/" {
+				t.Errorf("Expected the redirect location, Got %s", location)
+			}
+		})
+		wg.Go(func() {
+			if err := client.Healthcheck(); err != nil {
+				t.Error(err)
+			}
+			if _, err := client.ServerAPIVersion(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	apiVersion, platformVersion := client.Versions()
+	if apiVersion != drycc.APIVersion || platformVersion != "v9000" {
+		t.Errorf("Expected %s and v9000, Got %s and %s", drycc.APIVersion, apiVersion, platformVersion)
+	}
+
+	// Logging in must not change how other requests handle redirects.
+	if client.HTTPClient.CheckRedirect != nil {
+		t.Error("Expected the shared HTTP client to be left untouched")
+	}
+}
@@ -2,8 +2,9 @@
 package releases
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,87 +12,66 @@ import (
 
 // List lists an app's releases.
 func List(c *drycc.Client, appID, ptypes string, results int) ([]api.Release, int, error) {
+	return ListWithContext(context.Background(), c, appID, ptypes, results)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID, ptypes string, results int) ([]api.Release, int, error) {
 	u := fmt.Sprintf("/v2/apps/%s/releases/", appID)
 	if ptypes != "" {
 		u += fmt.Sprintf("?ptypes=%s", ptypes)
 	}
+	return drycc.List[api.Release](ctx, c, u, results)
+}
 
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Release{}, -1, reqErr
-	}
-
-	var releases []api.Release
-	if err := json.Unmarshal([]byte(body), &releases); err != nil {
-		return []api.Release{}, -1, err
+// All returns an iterator over every release of an app, optionally filtered by ptypes,
+// following the controller's pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID, ptypes string, pageSize int) iter.Seq2[api.Release, error] {
+	u := fmt.Sprintf("/v2/apps/%s/releases/", appID)
+	if ptypes != "" {
+		u += fmt.Sprintf("?ptypes=%s", ptypes)
 	}
-
-	return releases, count, reqErr
+	return drycc.All[api.Release](ctx, c, u, pageSize)
 }
 
 // Get retrieves a release of an app.
 func Get(c *drycc.Client, appID string, version int) (api.Release, error) {
-	u := fmt.Sprintf("/v2/apps/%s/releases/v%d/", appID, version)
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Release{}, reqErr
-	}
-	defer res.Body.Close()
-
-	release := api.Release{}
-	if err := json.NewDecoder(res.Body).Decode(&release); err != nil {
-		return api.Release{}, err
-	}
+	return GetWithContext(context.Background(), c, appID, version)
+}
 
-	return release, nil
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string, version int) (api.Release, error) {
+	return drycc.Get[api.Release](ctx, c, fmt.Sprintf("/v2/apps/%s/releases/v%d/", appID, version))
 }
 
 // Deploy deploy an app's processes. To deploy all app processes, pass empty strings for
 // procType and name. To deploy an specific process, pass an procType by leave name empty.
 // To deploy a specific instance, pass a procType and a name.
 func Deploy(c *drycc.Client, appID string, targets map[string]any) error {
-	u := fmt.Sprintf("/v2/apps/%s/releases/deploy/", appID)
-	body, err := json.Marshal(targets)
-	if err != nil {
-		return err
-	}
-	res, err := c.Request("POST", u, body)
-	if err != nil && !drycc.IsErrAPIMismatch(err) {
-		return err
-	}
-	defer res.Body.Close()
-	return err
+	return DeployWithContext(context.Background(), c, appID, targets)
+}
+
+// DeployWithContext is like [Deploy] but carries ctx.
+func DeployWithContext(ctx context.Context, c *drycc.Client, appID string, targets map[string]any) error {
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/releases/deploy/", appID), targets)
 }
 
 // Rollback rolls back an app to a previous release. If version is -1, this rolls back to
 // the previous release. Otherwise, roll back to the specified version.
 func Rollback(c *drycc.Client, appID string, ptypes string, version int) (int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/releases/rollback/", appID)
-
-	req := api.ReleaseRollback{Ptypes: ptypes, Version: version}
+	return RollbackWithContext(context.Background(), c, appID, ptypes, version)
+}
 
-	var err error
-	var reqBody []byte
+// RollbackWithContext is like [Rollback] but carries ctx.
+func RollbackWithContext(ctx context.Context, c *drycc.Client, appID string, ptypes string, version int) (int, error) {
+	var req any
 	if version != -1 {
-		reqBody, err = json.Marshal(req)
-		if err != nil {
-			return -1, err
-		}
-	}
-
-	res, reqErr := c.Request("POST", u, reqBody)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return -1, reqErr
+		req = api.ReleaseRollback{Ptypes: ptypes, Version: version}
 	}
-	defer res.Body.Close()
 
-	response := api.ReleaseRollback{}
-
-	if err = json.NewDecoder(res.Body).Decode(&response); err != nil {
+	res, err := drycc.Post[api.ReleaseRollback](ctx, c, fmt.Sprintf("/v2/apps/%s/releases/rollback/", appID), req)
+	if err != nil {
 		return -1, err
 	}
-
-	return response.Version, reqErr
+	return res.Version, nil
 }
@@ -2,8 +2,9 @@
 package resources
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,124 +12,86 @@ import (
 
 // Services is list all available resource services
 func Services(c *drycc.Client, results int) (api.ResourceServices, int, error) {
-	u := "/v2/resources/services/"
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.ResourceService{}, -1, reqErr
-	}
-	var services []api.ResourceService
-	if err := json.Unmarshal([]byte(body), &services); err != nil {
-		return []api.ResourceService{}, -1, err
-	}
-	return services, count, reqErr
+	return ServicesWithContext(context.Background(), c, results)
+}
+
+// ServicesWithContext is like [Services] but carries ctx.
+func ServicesWithContext(ctx context.Context, c *drycc.Client, results int) (api.ResourceServices, int, error) {
+	return drycc.List[api.ResourceService](ctx, c, "/v2/resources/services/", results)
 }
 
 // Plans is list all available resource services
 func Plans(c *drycc.Client, serviceName string, results int) (api.ResourcePlans, int, error) {
-	u := fmt.Sprintf("/v2/resources/services/%s/plans/", serviceName)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.ResourcePlan{}, -1, reqErr
-	}
-	var plans []api.ResourcePlan
-	if err := json.Unmarshal([]byte(body), &plans); err != nil {
-		return []api.ResourcePlan{}, -1, err
-	}
-	return plans, count, reqErr
+	return PlansWithContext(context.Background(), c, serviceName, results)
+}
+
+// PlansWithContext is like [Plans] but carries ctx.
+func PlansWithContext(ctx context.Context, c *drycc.Client, serviceName string, results int) (api.ResourcePlans, int, error) {
+	return drycc.List[api.ResourcePlan](ctx, c, fmt.Sprintf("/v2/resources/services/%s/plans/", serviceName), results)
 }
 
 // List list an app's resources.
 func List(c *drycc.Client, appID string, results int) (api.Resources, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/resources/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Resource{}, -1, reqErr
-	}
-	var resources []api.Resource
-	if err := json.Unmarshal([]byte(body), &resources); err != nil {
-		return []api.Resource{}, -1, err
-	}
-	return resources, count, reqErr
+	return ListWithContext(context.Background(), c, appID, results)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Resources, int, error) {
+	return drycc.List[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/", appID), results)
+}
+
+// All returns an iterator over every resource of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Resource, error] {
+	return drycc.All[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/", appID), pageSize)
 }
 
 // Create create an app's resource.
 func Create(c *drycc.Client, appID string, resource api.Resource) (api.Resource, error) {
-	body, err := json.Marshal(resource)
-	if err != nil {
-		return api.Resource{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/resources/", appID)
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil {
-		return api.Resource{}, reqErr
-	}
-	defer res.Body.Close()
-	newResource := api.Resource{}
-	if err = json.NewDecoder(res.Body).Decode(&newResource); err != nil {
-		return api.Resource{}, err
-	}
-	return newResource, reqErr
+	return CreateWithContext(context.Background(), c, appID, resource)
+}
+
+// CreateWithContext is like [Create] but carries ctx.
+func CreateWithContext(ctx context.Context, c *drycc.Client, appID string, resource api.Resource) (api.Resource, error) {
+	return drycc.Post[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/", appID), resource)
 }
 
 // Get retrieves information about a resource
 func Get(c *drycc.Client, appID string, name string) (api.Resource, error) {
-	u := fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil {
-		return api.Resource{}, reqErr
-	}
-	defer res.Body.Close()
-	resResource := api.Resource{}
-	if err := json.NewDecoder(res.Body).Decode(&resResource); err != nil {
-		return api.Resource{}, err
-	}
-	return resResource, reqErr
+	return GetWithContext(context.Background(), c, appID, name)
+}
+
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string, name string) (api.Resource, error) {
+	return drycc.Get[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name))
 }
 
 // Delete delete an app's resource.
 func Delete(c *drycc.Client, appID string, name string) error {
-	u := fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, appID, name)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, name string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name), nil)
 }
 
 // Put update resource
 func Put(c *drycc.Client, appID string, name string, resource api.Resource) (api.Resource, error) {
-	body, err := json.Marshal(resource)
-	if err != nil {
-		return api.Resource{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name)
-	res, reqErr := c.Request("PUT", u, body)
-	if reqErr != nil {
-		return api.Resource{}, reqErr
-	}
-	defer res.Body.Close()
-	newResource := api.Resource{}
-	if err = json.NewDecoder(res.Body).Decode(&newResource); err != nil {
-		return api.Resource{}, err
-	}
-	return newResource, reqErr
+	return PutWithContext(context.Background(), c, appID, name, resource)
+}
+
+// PutWithContext is like [Put] but carries ctx.
+func PutWithContext(ctx context.Context, c *drycc.Client, appID string, name string, resource api.Resource) (api.Resource, error) {
+	return drycc.Put[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/%s/", appID, name), resource)
 }
 
 // Binding servicebinding binding with a serviceinstance
 func Binding(c *drycc.Client, appID string, name string, resource api.ResourceBinding) (api.Resource, error) {
-	body, err := json.Marshal(resource)
-	if err != nil {
-		return api.Resource{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/resources/%s/binding/", appID, name)
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil {
-		return api.Resource{}, reqErr
-	}
-	defer res.Body.Close()
-	newResource := api.Resource{}
-	if err = json.NewDecoder(res.Body).Decode(&newResource); err != nil {
-		return api.Resource{}, err
-	}
-	return newResource, reqErr
+	return BindingWithContext(context.Background(), c, appID, name, resource)
+}
+
+// BindingWithContext is like [Binding] but carries ctx.
+func BindingWithContext(ctx context.Context, c *drycc.Client, appID string, name string, resource api.ResourceBinding) (api.Resource, error) {
+	return drycc.Patch[api.Resource](ctx, c, fmt.Sprintf("/v2/apps/%s/resources/%s/binding/", appID, name), resource)
 }
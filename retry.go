@@ -0,0 +1,178 @@
+package drycc
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Clock abstracts the passage of time so that retry delays can be controlled in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy controls how requests that fail with a transient error are retried.
+// A Client without a RetryPolicy makes a single attempt per request.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. It doubles on every following retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, before jitter is applied.
+	MaxDelay time.Duration
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests. Only enable this if
+	// replaying a request that may have reached the controller is harmless.
+	RetryNonIdempotent bool
+
+	// RetryableStatus lists the response status codes that are retried.
+	// If empty, 429, 502, 503 and 504 are retried.
+	RetryableStatus []int
+
+	// Clock is used to wait between attempts. If nil, the system clock is used.
+	Clock Clock
+}
+
+// DefaultRetryPolicy returns a policy making up to 4 attempts of idempotent requests,
+// waiting 500ms, 1s and 2s (with jitter) between them.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+var defaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// canRetry reports whether req may be sent more than once.
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// shouldRetry reports whether the outcome of an attempt is a transient failure.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		// The caller gave up, retrying is pointless.
+		return ctx.Err() == nil
+	}
+	status := p.RetryableStatus
+	if len(status) == 0 {
+		status = defaultRetryableStatus
+	}
+	return slices.Contains(status, res.StatusCode)
+}
+
+// delay returns how long to wait after the given failed attempt, honouring the
+// Retry-After header of res if there is one.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if d, ok := retryAfter(res.Header.Get("Retry-After"), p.clock().Now()); ok {
+			return d
+		}
+	}
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Equal jitter: wait between half and the full backoff.
+	return d/2 + rand.N(d/2+1)
+}
+
+func (p *RetryPolicy) clock() Clock {
+	if p.Clock == nil {
+		return systemClock{}
+	}
+	return p.Clock
+}
+
+// wait blocks for d or until ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.clock().After(d):
+		return nil
+	}
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// send sends req through the middlewares and the HTTP client, retrying transient failures as allowed by
+// the client's RetryPolicy.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	doer := c.doer()
+	p := c.RetryPolicy
+	if p == nil || !p.canRetry(req) {
+		return doer.Do(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		res, err := doer.Do(req)
+		if attempt >= p.MaxAttempts || !p.shouldRetry(ctx, res, err) {
+			return res, err
+		}
+		delay := p.delay(attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		if err := p.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
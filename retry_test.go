@@ -0,0 +1,221 @@
+package drycc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	waited []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.waited = append(f.waited, d)
+	f.now = f.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- f.now
+	return ch
+}
+
+// flakyServer fails the first Failures requests with Status.
+type flakyServer struct {
+	Failures   int
+	Status     int
+	RetryAfter string
+
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (f *flakyServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("DRYCC_API_VERSION", APIVersion)
+	body, _ := io.ReadAll(req.Body)
+
+	f.mu.Lock()
+	f.bodies = append(f.bodies, string(body))
+	attempt := len(f.bodies)
+	f.mu.Unlock()
+
+	if attempt <= f.Failures {
+		if f.RetryAfter != "" {
+			res.Header().Add("Retry-After", f.RetryAfter)
+		}
+		res.WriteHeader(f.Status)
+		res.Write([]byte("try again"))
+		return
+	}
+	res.Write([]byte("ok"))
+}
+
+func newRetryClient(t *testing.T, url string, clock Clock) *Client {
+	t.Helper()
+	drycc, err := New(false, url, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    time.Minute,
+		Clock:       clock,
+	}
+	return drycc
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyServer{Failures: 2, Status: http.StatusBadGateway}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	drycc := newRetryClient(t, server.URL, clock)
+
+	res, err := drycc.Request("GET", "/v2/apps/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(handler.bodies) != 3 {
+		t.Errorf("Expected 3 attempts, Got %d", len(handler.bodies))
+	}
+
+	// Backoff doubles with equal jitter: [0.5s, 1s] then [1s, 2s].
+	if len(clock.waited) != 2 {
+		t.Fatalf("Expected 2 waits, Got %v", clock.waited)
+	}
+	if d := clock.waited[0]; d < 500*time.Millisecond || d > time.Second {
+		t.Errorf("Expected first delay within [500ms, 1s], Got %v", d)
+	}
+	if d := clock.waited[1]; d < time.Second || d > 2*time.Second {
+		t.Errorf("Expected second delay within [1s, 2s], Got %v", d)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyServer{Failures: 5, Status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc := newRetryClient(t, server.URL, &fakeClock{})
+
+	_, err := drycc.Request("DELETE", "/v2/apps/example-go/", nil)
+	expected := "unknown error (503): try again"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %s, Got %v", expected, err)
+	}
+
+	if len(handler.bodies) != 3 {
+		t.Errorf("Expected 3 attempts, Got %d", len(handler.bodies))
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyServer{Failures: 1, Status: http.StatusBadGateway}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc := newRetryClient(t, server.URL, &fakeClock{})
+
+	if _, err := drycc.Request("POST", "/v2/apps/", []byte("body")); err == nil {
+		t.Error("Expected POST not to be retried")
+	}
+	if len(handler.bodies) != 1 {
+		t.Errorf("Expected 1 attempt, Got %d", len(handler.bodies))
+	}
+
+	handler = &flakyServer{Failures: 1, Status: http.StatusBadGateway}
+	server2 := httptest.NewServer(handler)
+	defer server2.Close()
+
+	drycc = newRetryClient(t, server2.URL, &fakeClock{})
+	drycc.RetryPolicy.RetryNonIdempotent = true
+
+	res, err := drycc.Request("POST", "/v2/apps/", []byte("body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	// The body must be replayed on every attempt.
+	if len(handler.bodies) != 2 || handler.bodies[0] != "body" || handler.bodies[1] != "body" {
+		t.Errorf("Expected the body to be sent twice, Got %q", handler.bodies)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyServer{Failures: 1, Status: http.StatusTooManyRequests, RetryAfter: "7"}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	drycc := newRetryClient(t, server.URL, clock)
+
+	res, err := drycc.Request("GET", "/v2/apps/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(clock.waited) != 1 || clock.waited[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s wait, Got %v", clock.waited)
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d, ok := retryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	if !ok || d != 90*time.Second {
+		t.Errorf("Expected 1m30s, Got %v (%t)", d, ok)
+	}
+
+	if _, ok := retryAfter("soon", now); ok {
+		t.Error("Expected an invalid Retry-After to be ignored")
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyServer{Failures: 5, Status: http.StatusBadGateway}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc := newRetryClient(t, server.URL, nil)
+	drycc.RetryPolicy.BaseDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := drycc.RequestWithContext(ctx, "GET", "/v2/apps/", nil); err != context.DeadlineExceeded {
+		t.Errorf("Expected %v, Got %v", context.DeadlineExceeded, err)
+	}
+	if len(handler.bodies) != 1 {
+		t.Errorf("Expected 1 attempt, Got %d", len(handler.bodies))
+	}
+}
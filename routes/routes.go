@@ -2,9 +2,10 @@
 package routes
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -12,116 +13,95 @@ import (
 
 // List routes registered with an app.
 func List(c *drycc.Client, appID string, results int) (api.Routes, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/routes/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Route{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, appID, results)
+}
 
-	var routes []api.Route
-	if err := json.Unmarshal([]byte(body), &routes); err != nil {
-		return []api.Route{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Routes, int, error) {
+	return drycc.List[api.Route](ctx, c, fmt.Sprintf("/v2/apps/%s/routes/", appID), results)
+}
 
-	return routes, count, reqErr
+// All returns an iterator over every route of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Route, error] {
+	return drycc.All[api.Route](ctx, c, fmt.Sprintf("/v2/apps/%s/routes/", appID), pageSize)
 }
 
 // New adds a route to an app.
 func New(c *drycc.Client, appID, name, kind string, backendRefs ...api.BackendRefRequest) error {
-	u := fmt.Sprintf("/v2/apps/%s/routes/", appID)
+	return NewWithContext(context.Background(), c, appID, name, kind, backendRefs...)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID, name, kind string, backendRefs ...api.BackendRefRequest) error {
 	req := api.RouteCreateRequest{
 		Name:  name,
 		Kind:  kind,
 		Rules: []api.RequestRouteRule{{BackendRefs: backendRefs}},
 	}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-	return reqErr
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/routes/", appID), req)
 }
 
 // AttachGateway route attach a gateway.
 func AttachGateway(c *drycc.Client, appID string, name string, port int, gateway string) error {
-	u := fmt.Sprintf("/v2/apps/%s/routes/%s/attach/", appID, name)
+	return AttachGatewayWithContext(context.Background(), c, appID, name, port, gateway)
+}
 
+// AttachGatewayWithContext is like [AttachGateway] but carries ctx.
+func AttachGatewayWithContext(ctx context.Context, c *drycc.Client, appID string, name string, port int, gateway string) error {
 	req := api.RouteAttachRequest{Port: port, Gateway: gateway}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-
-	return reqErr
+	return drycc.Send(ctx, c, "PATCH", fmt.Sprintf("/v2/apps/%s/routes/%s/attach/", appID, name), req)
 }
 
 // DetachGateway route attach a gateway.
 func DetachGateway(c *drycc.Client, appID string, name string, port int, gateway string) error {
-	u := fmt.Sprintf("/v2/apps/%s/routes/%s/detach/", appID, name)
+	return DetachGatewayWithContext(context.Background(), c, appID, name, port, gateway)
+}
 
+// DetachGatewayWithContext is like [DetachGateway] but carries ctx.
+func DetachGatewayWithContext(ctx context.Context, c *drycc.Client, appID string, name string, port int, gateway string) error {
 	req := api.RouteDetachRequest{Port: port, Gateway: gateway}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-
-	return reqErr
+	return drycc.Send(ctx, c, "PATCH", fmt.Sprintf("/v2/apps/%s/routes/%s/detach/", appID, name), req)
 }
 
 // GetRule gets info rule of a route from an app.
 func GetRule(c *drycc.Client, appID string, name string) (string, error) {
+	return GetRuleWithContext(context.Background(), c, appID, name)
+}
+
+// GetRuleWithContext is like [GetRule] but carries ctx.
+func GetRuleWithContext(ctx context.Context, c *drycc.Client, appID string, name string) (string, error) {
+	// Rules are returned verbatim rather than decoded.
 	u := fmt.Sprintf("/v2/apps/%s/routes/%s/rules/", appID, name)
-	res, err := c.Request("GET", u, nil)
+	res, err := c.RequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return "", err
 	}
 	defer res.Body.Close()
+
 	respBytes, err := io.ReadAll(res.Body)
-	return string(respBytes), err
+	if err != nil {
+		return "", err
+	}
+	return string(respBytes), nil
 }
 
 // SetRule set rule of a route.
 func SetRule(c *drycc.Client, appID string, name string, rules string) error {
-	u := fmt.Sprintf("/v2/apps/%s/routes/%s/rules/", appID, name)
-	body, err := json.Marshal(rules)
-	if err != nil {
-		return err
-	}
-	res, err := c.Request("PUT", u, body)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return SetRuleWithContext(context.Background(), c, appID, name, rules)
+}
+
+// SetRuleWithContext is like [SetRule] but carries ctx.
+func SetRuleWithContext(ctx context.Context, c *drycc.Client, appID string, name string, rules string) error {
+	return drycc.Send(ctx, c, "PUT", fmt.Sprintf("/v2/apps/%s/routes/%s/rules/", appID, name), rules)
 }
 
 // Delete Delete a route from an app.
 func Delete(c *drycc.Client, appID string, name string) error {
-	u := fmt.Sprintf("/v2/apps/%s/routes/%s/", appID, name)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, appID, name)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, name string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/routes/%s/", appID, name), nil)
 }
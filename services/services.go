@@ -2,9 +2,8 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -12,36 +11,16 @@ import (
 
 // List services registered with an app.
 func List(c *drycc.Client, appID string) (api.Services, error) {
-	u := fmt.Sprintf("/v2/apps/%s/services/", appID)
-	res, reqErr := c.Request("GET", u, nil)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Service{}, reqErr
-	}
-
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return []api.Service{}, err
-	}
-
-	r := make(map[string]any)
-	if err = json.Unmarshal([]byte(body), &r); err != nil {
-		return []api.Service{}, err
-	}
-
-	out, err := json.Marshal(r["services"].([]any))
-	if err != nil {
-		return []api.Service{}, err
-	}
-
-	var services []api.Service
-	if err := json.Unmarshal([]byte(out), &services); err != nil {
-		return []api.Service{}, err
-	}
+	return ListWithContext(context.Background(), c, appID)
+}
 
-	return services, reqErr
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string) (api.Services, error) {
+	u := fmt.Sprintf("/v2/apps/%s/services/", appID)
+	res, reqErr := drycc.Get[struct {
+		Services api.Services `json:"services"`
+	}](ctx, c, u)
+	return res.Services, reqErr
 }
 
 // New adds a new service to an app. App should already exists.
@@ -50,36 +29,23 @@ func List(c *drycc.Client, appID string) (api.Services, error) {
 // for more about Procfile see this https://devcenter.heroku.com/articles/procfile
 // Ptype and pathPattern are mandatory and should have valid values.
 func New(c *drycc.Client, appID string, Ptype string, port int, protocol string, targetPort int) error {
-	u := fmt.Sprintf("/v2/apps/%s/services/", appID)
+	return NewWithContext(context.Background(), c, appID, Ptype, port, protocol, targetPort)
+}
 
+// NewWithContext is like [New] but carries ctx.
+func NewWithContext(ctx context.Context, c *drycc.Client, appID string, Ptype string, port int, protocol string, targetPort int) error {
 	req := api.ServiceCreateUpdateRequest{Ptype: Ptype, Port: port, Protocol: protocol, TargetPort: targetPort}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return reqErr
-	}
-	defer res.Body.Close()
-	return reqErr
+	return drycc.Send(ctx, c, "POST", fmt.Sprintf("/v2/apps/%s/services/", appID), req)
 }
 
 // Delete service from app
 // If given service for the app doesn't exists then error returned
 func Delete(c *drycc.Client, appID string, Ptype string, protocol string, port int) error {
-	u := fmt.Sprintf("/v2/apps/%s/services/", appID)
+	return DeleteWithContext(context.Background(), c, appID, Ptype, protocol, port)
+}
 
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, Ptype string, protocol string, port int) error {
 	req := api.ServiceDeleteRequest{Ptype: Ptype, Protocol: protocol, Port: port}
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return err
-	}
-
-	_, err = c.Request("DELETE", u, body)
-
-	return err
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/services/", appID), req)
 }
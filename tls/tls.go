@@ -2,7 +2,7 @@
 package tls
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -11,75 +11,74 @@ import (
 
 // Info displays an app's tls config.
 func Info(c *drycc.Client, app string) (api.TLS, error) {
-	u := fmt.Sprintf("/v2/apps/%s/tls/", app)
-
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil {
-		return api.TLS{}, reqErr
-	}
-	defer res.Body.Close()
-
-	tls := api.TLS{}
-	if err := json.NewDecoder(res.Body).Decode(&tls); err != nil {
-		return api.TLS{}, err
-	}
+	return InfoWithContext(context.Background(), c, app)
+}
 
-	return tls, reqErr
+// InfoWithContext is like [Info] but carries ctx.
+func InfoWithContext(ctx context.Context, c *drycc.Client, app string) (api.TLS, error) {
+	return drycc.Get[api.TLS](ctx, c, fmt.Sprintf("/v2/apps/%s/tls/", app))
 }
 
 // changeTLS enables the router to enforce https-only requests to the application.
-func changeTLS(c *drycc.Client, app string, httpsEnforced, certsAutoEnabled *bool, issuer *api.Issuer) (api.TLS, error) {
+func changeTLS(ctx context.Context, c *drycc.Client, app string, httpsEnforced, certsAutoEnabled *bool, issuer *api.Issuer) (api.TLS, error) {
 	t := api.NewTLS()
 	t.HTTPSEnforced = httpsEnforced
 	t.CertsAutoEnabled = certsAutoEnabled
 	t.Issuer = issuer
-	body, err := json.Marshal(t)
-	if err != nil {
-		return api.TLS{}, err
-	}
-
-	u := fmt.Sprintf("/v2/apps/%s/tls/", app)
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil {
-		return api.TLS{}, reqErr
-	}
-	defer res.Body.Close()
-
-	newTLS := api.TLS{}
-	if err = json.NewDecoder(res.Body).Decode(&newTLS); err != nil {
-		return api.TLS{}, err
-	}
-
-	return newTLS, reqErr
+	return drycc.Post[api.TLS](ctx, c, fmt.Sprintf("/v2/apps/%s/tls/", app), t)
 }
 
 // EnableHTTPSEnforced enables the router to enforce https-only requests to the application.
 func EnableHTTPSEnforced(c *drycc.Client, app string) (api.TLS, error) {
+	return EnableHTTPSEnforcedWithContext(context.Background(), c, app)
+}
+
+// EnableHTTPSEnforcedWithContext is like [EnableHTTPSEnforced] but carries ctx.
+func EnableHTTPSEnforcedWithContext(ctx context.Context, c *drycc.Client, app string) (api.TLS, error) {
 	b := true
-	return changeTLS(c, app, &b, nil, nil)
+	return changeTLS(ctx, c, app, &b, nil, nil)
 }
 
 // DisableHTTPSEnforced disables the router from enforcing https-only requests to the application.
 func DisableHTTPSEnforced(c *drycc.Client, app string) (api.TLS, error) {
+	return DisableHTTPSEnforcedWithContext(context.Background(), c, app)
+}
+
+// DisableHTTPSEnforcedWithContext is like [DisableHTTPSEnforced] but carries ctx.
+func DisableHTTPSEnforcedWithContext(ctx context.Context, c *drycc.Client, app string) (api.TLS, error) {
 	b := false
-	return changeTLS(c, app, &b, nil, nil)
+	return changeTLS(ctx, c, app, &b, nil, nil)
 }
 
 // EnableCertsAutoEnabled enables ACME to automatically generate certificates.
 func EnableCertsAutoEnabled(c *drycc.Client, app string) (api.TLS, error) {
+	return EnableCertsAutoEnabledWithContext(context.Background(), c, app)
+}
+
+// EnableCertsAutoEnabledWithContext is like [EnableCertsAutoEnabled] but carries ctx.
+func EnableCertsAutoEnabledWithContext(ctx context.Context, c *drycc.Client, app string) (api.TLS, error) {
 	b := true
-	return changeTLS(c, app, nil, &b, nil)
+	return changeTLS(ctx, c, app, nil, &b, nil)
 }
 
 // DisableCertsAutoEnabled disables ACME to automatically generate certificates.
 func DisableCertsAutoEnabled(c *drycc.Client, app string) (api.TLS, error) {
+	return DisableCertsAutoEnabledWithContext(context.Background(), c, app)
+}
+
+// DisableCertsAutoEnabledWithContext is like [DisableCertsAutoEnabled] but carries ctx.
+func DisableCertsAutoEnabledWithContext(ctx context.Context, c *drycc.Client, app string) (api.TLS, error) {
 	b := false
-	return changeTLS(c, app, nil, &b, nil)
+	return changeTLS(ctx, c, app, nil, &b, nil)
 }
 
 // AddCertsIssuer disables ACME to automatically generate certificates.
 func AddCertsIssuer(c *drycc.Client, app string, email string, server string, keyID string, keySecret string) (api.TLS, error) {
+	return AddCertsIssuerWithContext(context.Background(), c, app, email, server, keyID, keySecret)
+}
+
+// AddCertsIssuerWithContext is like [AddCertsIssuer] but carries ctx.
+func AddCertsIssuerWithContext(ctx context.Context, c *drycc.Client, app string, email string, server string, keyID string, keySecret string) (api.TLS, error) {
 	issuer := api.Issuer{Email: email, Server: server, KeyID: keyID, KeySecret: keySecret}
-	return changeTLS(c, app, nil, nil, &issuer)
+	return changeTLS(ctx, c, app, nil, nil, &issuer)
 }
@@ -0,0 +1,139 @@
+package drycc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WithCAFile trusts the PEM encoded CA certificates in the file, in addition to the
+// system roots unless WithSystemCAs(false) is given.
+func WithCAFile(path string) Option {
+	return func(o *options) {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			o.err = errors.Join(o.err, fmt.Errorf("reading CA bundle: %w", err))
+			return
+		}
+		o.caPEMs = append(o.caPEMs, pem)
+	}
+}
+
+// WithCAPEM trusts the PEM encoded CA certificates, in addition to the system roots
+// unless WithSystemCAs(false) is given.
+func WithCAPEM(pem []byte) Option {
+	return func(o *options) {
+		o.caPEMs = append(o.caPEMs, pem)
+	}
+}
+
+// WithSystemCAs controls whether the system roots are trusted along with the CA
+// certificates given by WithCAFile and WithCAPEM. They are trusted by default.
+func WithSystemCAs(enabled bool) Option {
+	return func(o *options) {
+		o.noSystemCAs = !enabled
+	}
+}
+
+// WithClientCertificate presents the certificate and key, read from PEM encoded files,
+// to controllers requiring mutual TLS.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(o *options) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			o.err = errors.Join(o.err, fmt.Errorf("loading client certificate: %w", err))
+			return
+		}
+		o.certificates = append(o.certificates, cert)
+	}
+}
+
+// WithClientKeyPair presents the certificate to controllers requiring mutual TLS.
+func WithClientKeyPair(cert tls.Certificate) Option {
+	return func(o *options) {
+		o.certificates = append(o.certificates, cert)
+	}
+}
+
+// WithPinnedSPKI only accepts controller certificate chains containing a certificate whose
+// public key matches one of the pins. The chains built while verifying the certificate
+// are matched, or only the controller's own certificate if SSL verification is disabled.
+// A pin is the base64 encoded SHA-256 hash of the certificate's SubjectPublicKeyInfo,
+// optionally prefixed with "sha256/", such as:
+//
+//	openssl x509 -pubkey -noout -in cert.pem | openssl pkey -pubin -outform der |
+//	    openssl dgst -sha256 -binary | base64
+//
+// Pins are checked even if SSL verification is disabled.
+func WithPinnedSPKI(pins ...string) Option {
+	return func(o *options) {
+		for _, pin := range pins {
+			hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, "sha256/"))
+			if err != nil || len(hash) != sha256.Size {
+				o.err = errors.Join(o.err, fmt.Errorf("invalid SPKI pin %q", pin))
+				continue
+			}
+			o.pins = append(o.pins, string(hash))
+		}
+	}
+}
+
+// SPKIHash returns the pin of a certificate, as accepted by WithPinnedSPKI.
+func SPKIHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// tlsConfig builds the TLS configuration used for REST requests and websockets.
+func (o options) tlsConfig(sslVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: !sslVerify,
+		Certificates:       o.certificates,
+	}
+
+	if len(o.caPEMs) > 0 {
+		pool := x509.NewCertPool()
+		if !o.noSystemCAs {
+			system, err := x509.SystemCertPool()
+			if err != nil {
+				return nil, err
+			}
+			pool = system
+		}
+		for _, pem := range o.caPEMs {
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no valid certificate found in CA bundle")
+			}
+		}
+		config.RootCAs = pool
+	}
+
+	if len(o.pins) > 0 {
+		pins := o.pins
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			// The peer can send any certificate along with its own, so only the verified
+			// chains, or the leaf whose key the handshake proved, are matched.
+			chains := cs.VerifiedChains
+			if !sslVerify && len(cs.PeerCertificates) > 0 {
+				chains = [][]*x509.Certificate{cs.PeerCertificates[:1]}
+			}
+			for _, chain := range chains {
+				for _, cert := range chain {
+					hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+					for _, pin := range pins {
+						if string(hash[:]) == pin {
+							return nil
+						}
+					}
+				}
+			}
+			return errors.New("controller certificate doesn't match any pinned public key")
+		}
+	}
+	return config, nil
+}
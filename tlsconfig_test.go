@@ -0,0 +1,230 @@
+package drycc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// newTLSServer starts a TLS server returning the PEM encoding of its certificate.
+func newTLSServer(t *testing.T, handler http.Handler) (*httptest.Server, []byte) {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	return server, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+// newClientCertificate creates a self-signed client certificate, returning the PEM
+// encoded certificate and key.
+func newClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "drycc-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// newServerCertificate creates a self-signed certificate for 127.0.0.1.
+func newServerCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "drycc-controller"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func healthcheck(t *testing.T, url string, verifySSL bool, opts ...Option) error {
+	t.Helper()
+	drycc, err := New(verifySSL, url, "", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+	return drycc.Healthcheck()
+}
+
+func TestCABundle(t *testing.T) {
+	t.Parallel()
+
+	server, caPEM := newTLSServer(t, fakeHTTPServer{Version: APIVersion})
+
+	if err := healthcheck(t, server.URL, true); err == nil {
+		t.Error("Expected the untrusted certificate to be rejected")
+	}
+
+	if err := healthcheck(t, server.URL, true, WithCAPEM(caPEM), WithSystemCAs(false)); err != nil {
+		t.Error(err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := healthcheck(t, server.URL, true, WithCAFile(caFile)); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := New(true, server.URL, "", WithCAFile(filepath.Join(t.TempDir(), "missing.pem"))); err == nil {
+		t.Error("Expected an error for a missing CA bundle")
+	}
+	if _, err := New(true, server.URL, "", WithCAPEM([]byte("garbage"))); err == nil {
+		t.Error("Expected an error for an invalid CA bundle")
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(fakeHTTPServer{Version: APIVersion})
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	if err := healthcheck(t, server.URL, false); err == nil {
+		t.Error("Expected the server to require a client certificate")
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := healthcheck(t, server.URL, false, WithClientCertificate(certFile, keyFile)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPinnedSPKI(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTLSServer(t, fakeHTTPServer{Version: APIVersion})
+	pin := SPKIHash(server.Certificate())
+
+	if err := healthcheck(t, server.URL, false, WithPinnedSPKI("sha256/"+pin)); err != nil {
+		t.Error(err)
+	}
+
+	other := SPKIHash(&x509.Certificate{RawSubjectPublicKeyInfo: []byte("other")})
+	if err := healthcheck(t, server.URL, false, WithPinnedSPKI(other)); err == nil {
+		t.Error("Expected a certificate not matching the pin to be rejected")
+	}
+
+	// A pinned certificate sent along with a certificate whose key isn't pinned doesn't
+	// match, with or without verification.
+	cert := newServerCertificate(t)
+	cert.Certificate = append(cert.Certificate, server.Certificate().Raw)
+	forged := httptest.NewUnstartedServer(fakeHTTPServer{Version: APIVersion})
+	forged.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	forged.StartTLS()
+	defer forged.Close()
+	leafPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Leaf.Raw})
+	if err := healthcheck(t, forged.URL, true, WithCAPEM(leafPEM), WithPinnedSPKI(SPKIHash(cert.Leaf))); err != nil {
+		t.Error(err)
+	}
+	if err := healthcheck(t, forged.URL, false, WithPinnedSPKI(pin)); err == nil {
+		t.Error("Expected an appended pinned certificate to be rejected without verification")
+	}
+	if err := healthcheck(t, forged.URL, true, WithCAPEM(leafPEM), WithPinnedSPKI(pin)); err == nil {
+		t.Error("Expected an appended pinned certificate to be rejected with verification")
+	}
+
+	if _, err := New(false, server.URL, "", WithPinnedSPKI("not a pin")); err == nil {
+		t.Error("Expected an error for an invalid pin")
+	}
+}
+
+func TestWebsocketTLS(t *testing.T) {
+	t.Parallel()
+
+	server, caPEM := newTLSServer(t, websocket.Handler(func(conn *websocket.Conn) {
+		conn.Write([]byte(conn.Request().URL.Path))
+	}))
+
+	drycc, err := New(true, server.URL, "abc", WithCAPEM(caPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := drycc.NewWebsocketConfig("v2/apps/example-go/pods/example-go-web-111/exec/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Header.Get("Authorization") != "token abc" {
+		t.Errorf("Expected token abc, Got %s", config.Header.Get("Authorization"))
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var path string
+	if err = websocket.Message.Receive(conn, &path); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "/v2/apps/example-go/pods/example-go-web-111/exec/"; path != expected {
+		t.Errorf("Expected %s, Got %s", expected, path)
+	}
+}
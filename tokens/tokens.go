@@ -2,35 +2,156 @@
 package tokens
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/auth"
+	drycctime "github.com/drycc/controller-sdk-go/pkg/time"
 )
 
+// ErrTokenNotFound is returned by Rotate when the user has no token with the given UUID.
+var ErrTokenNotFound = errors.New("no token with this UUID")
+
 // List tokens.
 func List(c *drycc.Client, results int) ([]api.Token, int, error) {
-	body, count, reqErr := c.LimitedRequest("/v2/tokens/", results)
+	return ListWithContext(context.Background(), c, results)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, results int) ([]api.Token, int, error) {
+	return drycc.List[api.Token](ctx, c, "/v2/tokens/", results)
+}
+
+// All returns an iterator over every token of the user, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, pageSize int) iter.Seq2[api.Token, error] {
+	return drycc.All[api.Token](ctx, c, "/v2/tokens/", pageSize)
+}
+
+// Delete a token
+func Delete(c *drycc.Client, id string) error {
+	return DeleteWithContext(context.Background(), c, id)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, id string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/tokens/%s/", id), nil)
+}
+
+// Create creates a token of the user with the given alias. The key of the token is only
+// returned now and can't be retrieved later.
+func Create(c *drycc.Client, alias string) (api.TokenCreateResponse, error) {
+	return CreateWithContext(context.Background(), c, alias)
+}
+
+// CreateWithContext is like [Create] but carries ctx.
+func CreateWithContext(ctx context.Context, c *drycc.Client, alias string) (api.TokenCreateResponse, error) {
+	req := api.TokenCreateRequest{Alias: alias}
+	return drycc.Post[api.TokenCreateResponse](ctx, c, "/v2/tokens/", req)
+}
+
+// Rotate replaces the token with the given UUID by a new token with the same alias. The
+// new token is checked to authenticate with the controller before the old one is revoked,
+// so that a failed rotation leaves the old token working.
+//
+// If the old token can't be revoked, the new token is returned along with the error. When
+// rotating the token of c, switch c to the new token afterwards with c.SetToken.
+func Rotate(c *drycc.Client, id string) (api.TokenCreateResponse, error) {
+	return RotateWithContext(context.Background(), c, id)
+}
 
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Token{}, -1, reqErr
+// RotateWithContext is like [Rotate] but carries ctx.
+func RotateWithContext(ctx context.Context, c *drycc.Client, id string) (api.TokenCreateResponse, error) {
+	old, err := find(ctx, c, id)
+	if err != nil {
+		return api.TokenCreateResponse{}, err
 	}
 
-	var tokens []api.Token
-	if err := json.Unmarshal([]byte(body), &tokens); err != nil {
-		return []api.Token{}, -1, err
+	token, err := CreateWithContext(ctx, c, old.Alias)
+	if err != nil {
+		return api.TokenCreateResponse{}, err
+	}
+	if _, err := auth.WhoamiWithContext(drycc.WithToken(ctx, token.Token), c); err != nil {
+		// Don't leave an unusable token behind.
+		DeleteWithContext(ctx, c, token.UUID)
+		return api.TokenCreateResponse{}, fmt.Errorf("verifying the new token: %w", err)
 	}
 
-	return tokens, count, reqErr
+	if err := DeleteWithContext(ctx, c, old.UUID); err != nil {
+		return token, fmt.Errorf("revoking the old token: %w", err)
+	}
+	return token, nil
 }
 
-// Delete a token
-func Delete(c *drycc.Client, id string) error {
-	u := fmt.Sprintf("/v2/tokens/%s/", id)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
+// Prune revokes the tokens of the user that haven't been used since the given time,
+// according to their Updated field, and returns them. This includes the token of c if it
+// is that old, which is then revoked last so that it still authenticates the others.
+// Tokens whose Updated field can't be parsed are kept.
+//
+// On error, the tokens revoked so far are returned along with the error.
+func Prune(c *drycc.Client, since time.Time) ([]api.Token, error) {
+	return PruneWithContext(context.Background(), c, since)
+}
+
+// PruneWithContext is like [Prune] but carries ctx.
+func PruneWithContext(ctx context.Context, c *drycc.Client, since time.Time) ([]api.Token, error) {
+	// Collect the tokens first, as revoking them shifts the following pages.
+	tokens, err := drycc.ListAll[api.Token](ctx, c, "/v2/tokens/", drycc.DefaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []api.Token
+	own := -1
+	for _, token := range tokens {
+		var updated drycctime.Time
+		if err := updated.UnmarshalText([]byte(token.Updated)); err != nil || !updated.Before(since) {
+			continue
+		}
+		if own < 0 && matchesKey(token, c.CurrentToken()) {
+			own = len(stale)
+		}
+		stale = append(stale, token)
+	}
+	if own >= 0 {
+		token := stale[own]
+		stale = append(slices.Delete(stale, own, own+1), token)
+	}
+
+	var pruned []api.Token
+	for _, token := range stale {
+		if err := DeleteWithContext(ctx, c, token.UUID); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, token)
+	}
+	return pruned, nil
+}
+
+// matchesKey reports whether key may be the key of token, whose fuzzy key only shows the
+// start and the end of its key around "...".
+func matchesKey(token api.Token, key string) bool {
+	prefix, suffix, ok := strings.Cut(token.Key, "...")
+	return ok && key != "" && len(key) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix)
+}
+
+// find returns the token of the user with the given UUID.
+func find(ctx context.Context, c *drycc.Client, id string) (api.Token, error) {
+	for token, err := range All(ctx, c, drycc.DefaultPageSize) {
+		if err != nil {
+			return api.Token{}, err
+		}
+		if token.UUID == id {
+			return token, nil
+		}
 	}
-	return err
+	return api.Token{}, fmt.Errorf("%w: %s", ErrTokenNotFound, id)
 }
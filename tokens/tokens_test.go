@@ -1,14 +1,20 @@
 package tokens
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
+	"github.com/drycc/controller-sdk-go/auth"
+	"github.com/drycc/controller-sdk-go/drycctest"
 )
 
 const tokensFixture string = `
@@ -46,6 +52,32 @@ func (fakeHTTPServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if req.URL.Path == "/v2/tokens/" && req.Method == "POST" {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			fmt.Println(err)
+			res.WriteHeader(http.StatusInternalServerError)
+			res.Write(nil)
+			return
+		}
+
+		if string(body) != `{"alias":"ci"}` {
+			fmt.Printf("Expected '{\"alias\":\"ci\"}', Got '%s'\n", body)
+			res.WriteHeader(http.StatusInternalServerError)
+			res.Write(nil)
+			return
+		}
+
+		res.WriteHeader(http.StatusCreated)
+		res.Write([]byte(`{"uuid":"0d9e8a46-d7c8-4ad1-a2a4-1c5d9b5e6f70","owner":"test","alias":"ci","token":"3f2b7c9d1e","created":"2023-04-20T00:00:00UTC"}`))
+		return
+	}
+
+	if req.URL.Path == "/v2/tokens/f71e3b18-e702-409e-bd7f-8fb0a66d7b12/" && req.Method == "DELETE" {
+		res.WriteHeader(204)
+		return
+	}
+
 	if req.URL.Path == "/v2/tokens/f71e3b18-e702-499e-bd7f-8fb0a66d7b12/" && req.Method == "DELETE" {
 		res.WriteHeader(204)
 		return
@@ -113,3 +145,153 @@ func TestTokensRemove(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTokensAll(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var aliases []string
+	for token, err := range All(context.Background(), drycc, 1) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		aliases = append(aliases, token.Alias)
+	}
+
+	expected := []string{"", "test"}
+	if !reflect.DeepEqual(expected, aliases) {
+		t.Errorf("Expected %v, Got %v", expected, aliases)
+	}
+}
+
+func TestTokensCreate(t *testing.T) {
+	t.Parallel()
+
+	expected := api.TokenCreateResponse{
+		UUID:    "0d9e8a46-d7c8-4ad1-a2a4-1c5d9b5e6f70",
+		Owner:   "test",
+		Alias:   "ci",
+		Token:   "3f2b7c9d1e",
+		Created: "2023-04-20T00:00:00UTC",
+	}
+	handler := fakeHTTPServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := Create(drycc, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected != actual {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+}
+
+func TestTokensPrune(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the first token was last used before noon.
+	pruned, err := Prune(drycc, time.Date(2023, 4, 19, 6, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 || pruned[0].UUID != "f71e3b18-e702-409e-bd7f-8fb0a66d7b12" {
+		t.Errorf("Expected the first token to be pruned, Got %v", pruned)
+	}
+}
+
+func TestTokensPruneOwnToken(t *testing.T) {
+	t.Parallel()
+
+	server := drycctest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, alias := range []string{"ci", "deploy"} {
+		if _, err := Create(client, alias); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every token is stale, starting with the one of the client.
+	pruned, err := Prune(client, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aliases []string
+	for _, token := range pruned {
+		aliases = append(aliases, token.Alias)
+	}
+	if len(pruned) != 3 || pruned[2].Key != drycctest.DefaultToken[:6]+"..." {
+		t.Errorf("Expected the token of the client to be revoked last, Got %v", aliases)
+	}
+	if _, err := auth.Whoami(client); !errors.Is(err, drycc.ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrUnauthorized, err)
+	}
+}
+
+func TestTokensRotate(t *testing.T) {
+	t.Parallel()
+
+	server := drycctest.NewServer()
+	defer server.Close()
+
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, _, err := List(client, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := Rotate(client, old[0].UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token == drycctest.DefaultToken || token.Alias != old[0].Alias {
+		t.Errorf("Expected a new token aliased %q, Got %v", old[0].Alias, token)
+	}
+
+	// The old token is revoked, the new one works.
+	if _, err := auth.Whoami(client); !errors.Is(err, drycc.ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", drycc.ErrUnauthorized, err)
+	}
+	client.Token = token.Token
+	if _, err := auth.Whoami(client); err != nil {
+		t.Error(err)
+	}
+	if server.Token() != token.Token {
+		t.Errorf("Expected %s, Got %s", token.Token, server.Token())
+	}
+
+	if _, err := Rotate(client, old[0].UUID); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected %v, Got %v", ErrTokenNotFound, err)
+	}
+}
@@ -0,0 +1,123 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TokenSource supplies a new token when the controller rejects the token of a client,
+// for example because it was revoked. It is analogous to oauth2.TokenSource.
+type TokenSource interface {
+	// Token returns a token to replace stale, the token the controller rejected.
+	Token(ctx context.Context, stale string) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context, stale string) (string, error)
+
+// Token calls f(ctx, stale).
+func (f TokenSourceFunc) Token(ctx context.Context, stale string) (string, error) {
+	return f(ctx, stale)
+}
+
+// StoreTokenSource returns a TokenSource reading the token of a user from store, so that
+// a long-running program picks up the token of a new login made by another program, such
+// as the Drycc CLI. It fails with ErrUnauthorized if the store still holds the stale token.
+func StoreTokenSource(store CredentialStore, controller, username string) TokenSource {
+	return TokenSourceFunc(func(_ context.Context, stale string) (string, error) {
+		token, err := store.Get(controller, username)
+		if err != nil {
+			return "", err
+		}
+		if token == stale {
+			return "", ErrUnauthorized
+		}
+		return token, nil
+	})
+}
+
+// tokenRefresh is a refresh of the token of a client in progress.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// tokenRefreshTimeout bounds a refresh of the token, which doesn't stop when the request
+// that started it is canceled, as other requests may be waiting for it.
+const tokenRefreshTimeout = 30 * time.Second
+
+// CurrentToken returns the token requests are authenticated with. Unlike reading the
+// Token field, it is safe while requests are in flight, which may refresh the token with
+// the TokenSource of the client.
+func (c *Client) CurrentToken() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.Token
+}
+
+// SetToken changes the token requests are authenticated with. Unlike setting the Token
+// field, it is safe while requests are in flight.
+func (c *Client) SetToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.Token = token
+}
+
+// refreshToken replaces stale, the token of a rejected request, using the TokenSource of
+// the client. Requests rejected at the same time share a single refresh, and requests
+// rejected with a token that was already replaced use the new token right away. The
+// refresh runs without the cancellation of ctx, so that canceling one request doesn't
+// fail the others waiting for it.
+func (c *Client) refreshToken(ctx context.Context, stale string) (string, error) {
+	c.tokenMu.Lock()
+	if c.Token != stale {
+		token := c.Token
+		c.tokenMu.Unlock()
+		return token, nil
+	}
+	r := c.refresh
+	if r == nil {
+		r = &tokenRefresh{done: make(chan struct{})}
+		c.refresh = r
+		go c.runRefresh(context.WithoutCancel(ctx), r, stale)
+	}
+	c.tokenMu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.token, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// runRefresh asks the TokenSource for a token replacing stale, and completes r.
+func (c *Client) runRefresh(ctx context.Context, r *tokenRefresh, stale string) {
+	ctx, cancel := context.WithTimeout(ctx, tokenRefreshTimeout)
+	defer cancel()
+	r.token, r.err = c.TokenSource.Token(ctx, stale)
+	if r.err == nil && r.token == "" {
+		r.err = errors.New("the token source returned an empty token")
+	}
+	if r.err != nil {
+		r.err = fmt.Errorf("refreshing the token: %w", r.err)
+	}
+
+	c.tokenMu.Lock()
+	// The token may have been changed with SetToken meanwhile.
+	if r.err == nil && c.Token == stale {
+		c.Token = r.token
+	}
+	c.refresh = nil
+	c.tokenMu.Unlock()
+	close(r.done)
+}
+
+// canReplay reports whether req can be sent again after being rejected.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
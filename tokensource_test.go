@@ -0,0 +1,188 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeTokenServer accepts a single token and echoes the body of requests.
+type fakeTokenServer struct {
+	token string
+}
+
+func (f fakeTokenServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("DRYCC_API_VERSION", APIVersion)
+	if req.Header.Get("Authorization") != "token "+f.token {
+		res.WriteHeader(http.StatusUnauthorized)
+		res.Write([]byte(`{"detail":"Invalid token."}`))
+		return
+	}
+	io.Copy(res, req.Body)
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTokenServer{token: "new"})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls atomic.Int32
+	drycc.TokenSource = TokenSourceFunc(func(_ context.Context, stale string) (string, error) {
+		calls.Add(1)
+		if stale != "old" {
+			t.Errorf("Expected old, Got %s", stale)
+		}
+		return "new", nil
+	})
+
+	// The body is sent again along with the new token.
+	actual, err := Post[map[string]string](context.Background(), drycc, "/echo/", map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual["a"] != "b" {
+		t.Errorf("Expected the body to be replayed, Got %v", actual)
+	}
+	if drycc.CurrentToken() != "new" {
+		t.Errorf("Expected new, Got %s", drycc.CurrentToken())
+	}
+
+	if err := Send(context.Background(), drycc, "GET", "/echo/", nil); err != nil {
+		t.Error(err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh, Got %d", n)
+	}
+
+	// Requests with their own token are left alone.
+	ctx := WithToken(context.Background(), "other")
+	if err := Send(ctx, drycc, "GET", "/echo/", nil); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", ErrUnauthorized, err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh, Got %d", n)
+	}
+}
+
+func TestTokenSourceConcurrent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTokenServer{token: "new"})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls atomic.Int32
+	drycc.TokenSource = TokenSourceFunc(func(context.Context, string) (string, error) {
+		calls.Add(1)
+		// Give the other requests time to be rejected too.
+		time.Sleep(50 * time.Millisecond)
+		return "new", nil
+	})
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Send(context.Background(), drycc, "GET", "/echo/", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh, Got %d", n)
+	}
+}
+
+func TestTokenSourceCanceled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTokenServer{token: "new"})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started, release := make(chan struct{}), make(chan struct{})
+	drycc.TokenSource = TokenSourceFunc(func(ctx context.Context, _ string) (string, error) {
+		close(started)
+		<-release
+		return "new", ctx.Err()
+	})
+
+	// The request starting the refresh is canceled while another one waits for it.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() { first <- Send(ctx, drycc, "GET", "/echo/", nil) }()
+	<-started
+	second := make(chan error)
+	go func() { second <- Send(context.Background(), drycc, "GET", "/echo/", nil) }()
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, Got %v", context.Canceled, err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("Expected no error, Got %v", err)
+	}
+	if drycc.CurrentToken() != "new" {
+		t.Errorf("Expected new, Got %s", drycc.CurrentToken())
+	}
+
+	drycc.SetToken("other")
+	if drycc.CurrentToken() != "other" {
+		t.Errorf("Expected other, Got %s", drycc.CurrentToken())
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTokenServer{token: "new"})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStore()
+	store.Set(server.URL, "test", "old")
+	drycc.TokenSource = StoreTokenSource(store, server.URL, "test")
+
+	// The store holds the stale token.
+	err = Send(context.Background(), drycc, "GET", "/echo/", nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected %v, Got %v", ErrUnauthorized, err)
+	}
+	if drycc.CurrentToken() != "old" {
+		t.Errorf("Expected old, Got %s", drycc.CurrentToken())
+	}
+
+	store.Set(server.URL, "test", "new")
+	if err := Send(context.Background(), drycc, "GET", "/echo/", nil); err != nil {
+		t.Error(err)
+	}
+
+	store.Delete(server.URL, "test")
+	drycc.Token = "revoked"
+	if err := Send(context.Background(), drycc, "GET", "/echo/", nil); !errors.Is(err, ErrNoCredential) || !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected %v and %v, Got %v", ErrUnauthorized, ErrNoCredential, err)
+	}
+}
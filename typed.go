@@ -0,0 +1,98 @@
+package drycc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// The typed helpers below send a request to a path of the controller and decode its JSON
+// response. A zero result is returned along with any error.
+//
+// A request body given as []byte is sent as is, nil sends no body, and any other value
+// is encoded as JSON.
+
+// Get fetches path and decodes the response into a T.
+func Get[T any](ctx context.Context, c *Client, path string) (T, error) {
+	return Decode[T](ctx, c, "GET", path, nil)
+}
+
+// Post sends body to path and decodes the response into a T.
+func Post[T any](ctx context.Context, c *Client, path string, body any) (T, error) {
+	return Decode[T](ctx, c, "POST", path, body)
+}
+
+// Put replaces path with body and decodes the response into a T.
+func Put[T any](ctx context.Context, c *Client, path string, body any) (T, error) {
+	return Decode[T](ctx, c, "PUT", path, body)
+}
+
+// Patch updates path with body and decodes the response into a T.
+func Patch[T any](ctx context.Context, c *Client, path string, body any) (T, error) {
+	return Decode[T](ctx, c, "PATCH", path, body)
+}
+
+// Delete deletes path, sending body if it isn't nil. The response is discarded.
+func Delete(ctx context.Context, c *Client, path string, body any) error {
+	return Send(ctx, c, "DELETE", path, body)
+}
+
+// Send sends a request and discards the response, for endpoints that answer without
+// content.
+func Send(ctx context.Context, c *Client, method, path string, body any) error {
+	res, err := send(ctx, c, method, path, body)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// Decode sends a request and decodes the response into a T. An empty response decodes to
+// the zero T.
+func Decode[T any](ctx context.Context, c *Client, method, path string, body any) (T, error) {
+	var result T
+
+	res, err := send(ctx, c, method, path, body)
+	if err != nil {
+		return result, err
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil && !errors.Is(err, io.EOF) {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// List fetches a page of at most results items from the list endpoint at path, and
+// returns them with the total count of items. The count is -1 on errors.
+func List[T any](ctx context.Context, c *Client, path string, results int) ([]T, int, error) {
+	page, err := c.PageWithContext(ctx, path, results)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	var items []T
+	if err := json.Unmarshal(page.Results, &items); err != nil {
+		return nil, -1, err
+	}
+	return items, page.Count, nil
+}
+
+func send(ctx context.Context, c *Client, method, path string, body any) (*http.Response, error) {
+	var b []byte
+	switch body := body.(type) {
+	case nil:
+	case []byte:
+		b = body
+	default:
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	return c.RequestWithContext(ctx, method, path, b)
+}
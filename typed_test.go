@@ -0,0 +1,135 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type fakeTypedServer struct {
+	Version string
+}
+
+func (f fakeTypedServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("DRYCC_API_VERSION", f.Version)
+
+	switch {
+	case req.URL.Path == "/item/" && req.Method == "GET":
+		res.Write([]byte(`{"test": "foo"}`))
+	case req.URL.Path == "/item/" && req.Method == "POST":
+		body, _ := io.ReadAll(req.Body)
+		res.WriteHeader(http.StatusCreated)
+		res.Write(body)
+	case req.URL.Path == "/item/" && req.Method == "DELETE":
+		res.WriteHeader(http.StatusNoContent)
+	case req.URL.Path == "/items/" && req.Method == "GET" && req.URL.RawQuery == "limit=2":
+		res.Write([]byte(`{"count": 3, "next": null, "previous": null,
+			"results": [{"test": "a"}, {"test": "b"}]}`))
+	case req.URL.Path == "/missing/":
+		res.WriteHeader(http.StatusNotFound)
+		res.Write([]byte(`{"detail": "Not found."}`))
+	default:
+		fmt.Printf("Unrecongized URL %s\n", req.URL)
+		res.WriteHeader(http.StatusNotFound)
+		res.Write(nil)
+	}
+}
+
+func TestTypedRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTypedServer{Version: APIVersion})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	item, err := Get[pagedItem](ctx, drycc, "/item/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (pagedItem{"foo"}); item != expected {
+		t.Errorf("Expected %v, Got %v", expected, item)
+	}
+
+	item, err = Post[pagedItem](ctx, drycc, "/item/", pagedItem{"bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (pagedItem{"bar"}); item != expected {
+		t.Errorf("Expected %v, Got %v", expected, item)
+	}
+
+	// Raw bodies are sent as is.
+	item, err = Post[pagedItem](ctx, drycc, "/item/", []byte(`{"test": "baz"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (pagedItem{"baz"}); item != expected {
+		t.Errorf("Expected %v, Got %v", expected, item)
+	}
+
+	if err = Delete(ctx, drycc, "/item/", nil); err != nil {
+		t.Error(err)
+	}
+
+	items, count, err := List[pagedItem](ctx, drycc, "/items/", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []pagedItem{{"a"}, {"b"}}; !reflect.DeepEqual(expected, items) {
+		t.Errorf("Expected %v, Got %v", expected, items)
+	}
+	if count != 3 {
+		t.Errorf("Expected %d, Got %d", 3, count)
+	}
+
+	if _, err = Get[pagedItem](ctx, drycc, "/missing/"); !errors.As(err, &ErrNotFound{}) {
+		t.Errorf("Expected %v, Got %v", ErrNotFound{}, err)
+	}
+	if _, count, err = List[pagedItem](ctx, drycc, "/missing/", 2); count != -1 || !errors.As(err, &ErrNotFound{}) {
+		t.Errorf("Expected -1 and %v, Got %d and %v", ErrNotFound{}, count, err)
+	}
+}
+
+func TestTypedRequestsStrict(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeTypedServer{Version: "1.0"})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.Strict = true
+	ctx := context.Background()
+
+	item, err := Get[pagedItem](ctx, drycc, "/item/")
+	if !IsErrAPIMismatch(err) {
+		t.Errorf("Expected %v, Got %v", ErrAPIMismatch, err)
+	}
+	if item != (pagedItem{}) {
+		t.Errorf("Expected a zero result, Got %v", item)
+	}
+
+	items, count, err := List[pagedItem](ctx, drycc, "/items/", 2)
+	if !IsErrAPIMismatch(err) {
+		t.Errorf("Expected %v, Got %v", ErrAPIMismatch, err)
+	}
+	if items != nil || count != -1 {
+		t.Errorf("Expected no items and -1, Got %v and %d", items, count)
+	}
+
+	if err = Delete(ctx, drycc, "/item/", nil); !IsErrAPIMismatch(err) {
+		t.Errorf("Expected %v, Got %v", ErrAPIMismatch, err)
+	}
+}
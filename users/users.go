@@ -0,0 +1,74 @@
+// Package users provides methods for administering the users of the controller. They
+// require the token of a superuser.
+package users
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+// List lists the users of the controller.
+func List(c *drycc.Client, results int) (api.Users, int, error) {
+	return ListWithContext(context.Background(), c, results)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, results int) (api.Users, int, error) {
+	return drycc.List[api.User](ctx, c, "/v2/users/", results)
+}
+
+// All returns an iterator over every user of the controller, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, pageSize int) iter.Seq2[api.User, error] {
+	return drycc.All[api.User](ctx, c, "/v2/users/", pageSize)
+}
+
+// Get fetches a user by username.
+func Get(c *drycc.Client, username string) (api.User, error) {
+	return GetWithContext(context.Background(), c, username)
+}
+
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, username string) (api.User, error) {
+	return drycc.Get[api.User](ctx, c, fmt.Sprintf("/v2/users/%s/", username))
+}
+
+// Activate allows a user to log in again, with PATCH /v2/users/<username>/enable/.
+func Activate(c *drycc.Client, username string) error {
+	return ActivateWithContext(context.Background(), c, username)
+}
+
+// ActivateWithContext is like [Activate] but carries ctx.
+func ActivateWithContext(ctx context.Context, c *drycc.Client, username string) error {
+	return drycc.Send(ctx, c, "PATCH", fmt.Sprintf("/v2/users/%s/enable/", username), nil)
+}
+
+// Deactivate prevents a user from logging in, without deleting the user, with
+// PATCH /v2/users/<username>/disable/.
+func Deactivate(c *drycc.Client, username string) error {
+	return DeactivateWithContext(context.Background(), c, username)
+}
+
+// DeactivateWithContext is like [Deactivate] but carries ctx.
+func DeactivateWithContext(ctx context.Context, c *drycc.Client, username string) error {
+	return drycc.Send(ctx, c, "PATCH", fmt.Sprintf("/v2/users/%s/disable/", username), nil)
+}
+
+// SetSuperuser grants the superuser status of a user with POST /v2/admin/perms/, or
+// revokes it with DELETE /v2/admin/perms/<username>/. The controller offers no endpoint
+// to change the staff status of users.
+func SetSuperuser(c *drycc.Client, username string, superuser bool) error {
+	return SetSuperuserWithContext(context.Background(), c, username, superuser)
+}
+
+// SetSuperuserWithContext is like [SetSuperuser] but carries ctx.
+func SetSuperuserWithContext(ctx context.Context, c *drycc.Client, username string, superuser bool) error {
+	if !superuser {
+		return drycc.Delete(ctx, c, fmt.Sprintf("/v2/admin/perms/%s/", username), nil)
+	}
+	return drycc.Send(ctx, c, "POST", "/v2/admin/perms/", api.AdminPermRequest{Username: username})
+}
@@ -0,0 +1,182 @@
+package users
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	drycc "github.com/drycc/controller-sdk-go"
+	"github.com/drycc/controller-sdk-go/api"
+)
+
+const usersFixture = `
+{
+  "count": 2,
+  "next": null,
+  "previous": null,
+  "results": [
+    {
+      "id": 1,
+      "last_login": "2026-03-24T00:00:00Z",
+      "is_superuser": true,
+      "username": "admin",
+      "first_name": "",
+      "last_name": "",
+      "email": "admin@example.com",
+      "is_staff": true,
+      "is_active": true,
+      "date_joined": "2026-03-01T00:00:00Z"
+    },
+    {
+      "id": 2,
+      "last_login": "2026-03-24T00:00:00Z",
+      "is_superuser": false,
+      "username": "test",
+      "first_name": "Test",
+      "last_name": "User",
+      "email": "test@example.com",
+      "is_staff": false,
+      "is_active": true,
+      "date_joined": "2026-03-02T00:00:00Z"
+    }
+  ]
+}`
+
+const userFixture = `
+{
+  "id": 2,
+  "last_login": "2026-03-24T00:00:00Z",
+  "is_superuser": false,
+  "username": "test",
+  "first_name": "Test",
+  "last_name": "User",
+  "email": "test@example.com",
+  "is_staff": false,
+  "is_active": true,
+  "date_joined": "2026-03-02T00:00:00Z"
+}`
+
+// actions maps the admin requests about the user test to their expected bodies.
+var actions = map[string]string{
+	"PATCH /v2/users/test/enable/":  "",
+	"PATCH /v2/users/test/disable/": "",
+	"POST /v2/admin/perms/":         `{"username":"test"}`,
+	"DELETE /v2/admin/perms/test/":  "",
+}
+
+type fakeHTTPServer struct{}
+
+func (fakeHTTPServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("DRYCC_API_VERSION", drycc.APIVersion)
+
+	if req.URL.Path == "/v2/users/" && req.Method == "GET" {
+		res.Write([]byte(usersFixture))
+		return
+	}
+	if req.URL.Path == "/v2/users/test/" && req.Method == "GET" {
+		res.Write([]byte(userFixture))
+		return
+	}
+	if expected, ok := actions[req.Method+" "+req.URL.Path]; ok {
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != expected {
+			fmt.Printf("Unexpected body '%s'\n", body)
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	fmt.Printf("Unrecognized URL %s\n", req.URL)
+	res.WriteHeader(http.StatusNotFound)
+}
+
+func TestUsersList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeHTTPServer{})
+	defer server.Close()
+
+	c, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, count, err := List(c, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("Expected %d, Got %d", 2, count)
+	}
+	expected := []string{"admin", "test"}
+	var actual []string
+	for _, user := range list {
+		actual = append(actual, user.Username)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+}
+
+func TestUsersGet(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeHTTPServer{})
+	defer server.Close()
+
+	c, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := api.User{
+		ID:         2,
+		LastLogin:  "2026-03-24T00:00:00Z",
+		Username:   "test",
+		FirstName:  "Test",
+		LastName:   "User",
+		Email:      "test@example.com",
+		IsActive:   true,
+		DateJoined: "2026-03-02T00:00:00Z",
+	}
+	actual, err := Get(c, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected != actual {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+}
+
+func TestUsersUpdate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeHTTPServer{})
+	defer server.Close()
+
+	c, err := drycc.New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Activate(c, "test"); err != nil {
+		t.Error(err)
+	}
+	if err := Deactivate(c, "test"); err != nil {
+		t.Error(err)
+	}
+	if err := SetSuperuser(c, "test", true); err != nil {
+		t.Error(err)
+	}
+	if err := SetSuperuser(c, "test", false); err != nil {
+		t.Error(err)
+	}
+	if err := Activate(c, "missing"); err == nil {
+		t.Error("Expected an error for a missing user")
+	}
+}
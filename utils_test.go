@@ -1,6 +1,7 @@
 package drycc
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,12 +20,17 @@ func TestCheckAPIVersions(t *testing.T) {
 		{"2.1", "1.2", ErrAPIMismatch},
 		{"2.1", "2.2", ErrAPIMismatch},
 		{"2.3", "2.0", nil},
+		{"2.10", "2.3", nil},
+		{"2.3", "2.10", ErrAPIMismatch},
+		{"2.3.1", "2.3", nil},
+		{"2", "2.3", ErrAPIMismatch},
+		{"2.x", "2.3", ErrAPIMismatch},
 	}
 
 	for _, check := range comparisons {
 		err := CheckAPICompatibility(check.Client, check.Server)
 
-		if err != check.Error {
+		if !errors.Is(err, check.Error) {
 			t.Errorf("%v: Expected %v, Got %v", check, check.Error, err)
 		}
 	}
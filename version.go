@@ -0,0 +1,137 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed major.minor.patch API version.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a version of the form "major.minor" or "major.minor.patch",
+// optionally prefixed with "v".
+func ParseVersion(version string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", version)
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is older than, equal to or newer than o.
+func (v Version) Compare(o Version) int {
+	for _, d := range [...]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// APIMismatchError is returned when the controller API version is incompatible with the
+// version required by the SDK or by a feature. It matches ErrAPIMismatch with [errors.Is].
+type APIMismatchError struct {
+	// ServerVersion is the API version reported by the controller.
+	ServerVersion string
+	// RequiredVersion is the API version the SDK or the feature requires.
+	RequiredVersion string
+	// Feature names the feature requiring RequiredVersion, empty for the SDK itself.
+	Feature string
+}
+
+func (e *APIMismatchError) Error() string {
+	server := e.ServerVersion
+	if server == "" {
+		server = "unknown"
+	}
+	if e.Feature != "" {
+		return fmt.Sprintf("%s: %s requires controller API version %s or newer, server has %s",
+			ErrAPIMismatch, e.Feature, e.RequiredVersion, server)
+	}
+	return fmt.Sprintf("%s: server has API version %s, SDK requires %s",
+		ErrAPIMismatch, server, e.RequiredVersion)
+}
+
+// Is reports whether target is ErrAPIMismatch.
+func (e *APIMismatchError) Is(target error) bool {
+	return target == ErrAPIMismatch
+}
+
+// CheckAPICompatibility checks if the server and client API versions are compatible.
+// The server is compatible if it has the same major version and the same or a newer
+// minor version as the client. Otherwise an *APIMismatchError is returned.
+func CheckAPICompatibility(serverAPIVersion, clientAPIVersion string) error {
+	mismatch := &APIMismatchError{ServerVersion: serverAPIVersion, RequiredVersion: clientAPIVersion}
+
+	server, err := ParseVersion(serverAPIVersion)
+	if err != nil {
+		return mismatch
+	}
+	client, err := ParseVersion(clientAPIVersion)
+	if err != nil {
+		return mismatch
+	}
+
+	if server.Major != client.Major || server.Minor < client.Minor {
+		return mismatch
+	}
+	return nil
+}
+
+// ServerAPIVersion returns the parsed API version of the controller, as reported by the
+// last response. It fails if no request has been made yet.
+func (c *Client) ServerAPIVersion() (Version, error) {
+	apiVersion, _ := c.Versions()
+	if apiVersion == "" {
+		return Version{}, errors.New("controller API version is not known yet")
+	}
+	return ParseVersion(apiVersion)
+}
+
+// RequireAPIVersion checks that the controller API version is at least minimum, so that
+// callers can guard features that only newer controllers support. The controller is
+// contacted to learn its version if no request has been made yet. If the controller is
+// older, an *APIMismatchError naming the feature is returned.
+func (c *Client) RequireAPIVersion(ctx context.Context, feature string, minimum string) error {
+	required, err := ParseVersion(minimum)
+	if err != nil {
+		return err
+	}
+	if apiVersion, _ := c.Versions(); apiVersion == "" {
+		if err := c.CheckConnectionWithContext(ctx); err != nil && !IsErrAPIMismatch(err) {
+			return err
+		}
+	}
+	apiVersion, _ := c.Versions()
+	mismatch := &APIMismatchError{ServerVersion: apiVersion, RequiredVersion: minimum, Feature: feature}
+	server, err := ParseVersion(apiVersion)
+	if err != nil {
+		return mismatch
+	}
+	if server.Major != required.Major || server.Compare(required) < 0 {
+		return mismatch
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+		valid    bool
+	}{
+		{"2.3", Version{2, 3, 0}, true},
+		{"2.10.1", Version{2, 10, 1}, true},
+		{"v1.0", Version{1, 0, 0}, true},
+		{"2", Version{}, false},
+		{"2.3.4.5", Version{}, false},
+		{"2.x", Version{}, false},
+		{"", Version{}, false},
+	}
+
+	for _, check := range tests {
+		actual, err := ParseVersion(check.input)
+		if (err == nil) != check.valid {
+			t.Errorf("%s: Expected valid=%t, Got %v", check.input, check.valid, err)
+		}
+		if actual != check.expected {
+			t.Errorf("%s: Expected %v, Got %v", check.input, check.expected, actual)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b     Version
+		expected int
+	}{
+		{Version{2, 10, 0}, Version{2, 3, 0}, 1},
+		{Version{2, 3, 0}, Version{2, 10, 0}, -1},
+		{Version{2, 3, 1}, Version{2, 3, 1}, 0},
+		{Version{1, 9, 9}, Version{2, 0, 0}, -1},
+	}
+
+	for _, check := range tests {
+		if actual := check.a.Compare(check.b); actual != check.expected {
+			t.Errorf("%v vs %v: Expected %d, Got %d", check.a, check.b, check.expected, actual)
+		}
+	}
+}
+
+func TestAPIMismatchError(t *testing.T) {
+	err := CheckAPICompatibility("2.1", "2.3")
+
+	var mismatch *APIMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *APIMismatchError, Got %T", err)
+	}
+
+	expected := "API Version Mismatch between server and drycc: server has API version 2.1, SDK requires 2.3"
+	if err.Error() != expected {
+		t.Errorf("Expected %s, Got %s", expected, err.Error())
+	}
+}
+
+func TestRequireAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	handler := fakeHTTPServer{Version: "2.10"}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.UserAgent = "test"
+
+	if _, err := drycc.ServerAPIVersion(); err == nil {
+		t.Error("Expected an error before any request")
+	}
+
+	// The controller is contacted to learn its version.
+	if err := drycc.RequireAPIVersion(context.Background(), "autoscale", "2.4"); err != nil {
+		t.Error(err)
+	}
+
+	actual, err := drycc.ServerAPIVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Version{2, 10, 0}); actual != expected {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+
+	err = drycc.RequireAPIVersion(context.Background(), "autoscale", "2.11")
+	if !errors.Is(err, ErrAPIMismatch) {
+		t.Errorf("Expected ErrAPIMismatch, Got %v", err)
+	}
+	expected := "API Version Mismatch between server and drycc: autoscale requires controller API version 2.11 or newer, server has 2.10"
+	if err.Error() != expected {
+		t.Errorf("Expected %s, Got %s", expected, err.Error())
+	}
+}
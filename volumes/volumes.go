@@ -3,8 +3,8 @@ package volumes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 
 	drycc "github.com/drycc/controller-sdk-go"
@@ -13,84 +13,59 @@ import (
 
 // List list an app's volumes.
 func List(c *drycc.Client, appID string, results int) (api.Volumes, int, error) {
-	u := fmt.Sprintf("/v2/apps/%s/volumes/", appID)
-	body, count, reqErr := c.LimitedRequest(u, results)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Volume{}, -1, reqErr
-	}
-	var volumes []api.Volume
-	if err := json.Unmarshal([]byte(body), &volumes); err != nil {
-		return []api.Volume{}, -1, err
-	}
-	return volumes, count, reqErr
+	return ListWithContext(context.Background(), c, appID, results)
+}
+
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, appID string, results int) (api.Volumes, int, error) {
+	return drycc.List[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/", appID), results)
+}
+
+// All returns an iterator over every volume of an app, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, appID string, pageSize int) iter.Seq2[api.Volume, error] {
+	return drycc.All[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/", appID), pageSize)
 }
 
 // Get an app's volume.
 func Get(c *drycc.Client, appID string, name string) (api.Volume, error) {
-	u := fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, name)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Volume{}, reqErr
-	}
-	defer res.Body.Close()
-
-	volume := api.Volume{}
-	if err := json.NewDecoder(res.Body).Decode(&volume); err != nil {
-		return volume, err
-	}
+	return GetWithContext(context.Background(), c, appID, name)
+}
 
-	return volume, nil
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, appID string, name string) (api.Volume, error) {
+	return drycc.Get[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, name))
 }
 
 // Create create an app's Volume.
 func Create(c *drycc.Client, appID string, volume api.Volume) (api.Volume, error) {
-	body, err := json.Marshal(volume)
-	if err != nil {
-		return api.Volume{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/volumes/", appID)
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil {
-		return api.Volume{}, reqErr
-	}
-	defer res.Body.Close()
-	newVolume := api.Volume{}
-	if err = json.NewDecoder(res.Body).Decode(&newVolume); err != nil {
-		return api.Volume{}, err
-	}
-	return newVolume, reqErr
+	return CreateWithContext(context.Background(), c, appID, volume)
+}
+
+// CreateWithContext is like [Create] but carries ctx.
+func CreateWithContext(ctx context.Context, c *drycc.Client, appID string, volume api.Volume) (api.Volume, error) {
+	return drycc.Post[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/", appID), volume)
 }
 
 // Expand create an app's Volume.
 func Expand(c *drycc.Client, appID string, volume api.Volume) (api.Volume, error) {
-	body, err := json.Marshal(volume)
-	if err != nil {
-		return api.Volume{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, volume.Name)
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil {
-		return api.Volume{}, reqErr
-	}
-	defer res.Body.Close()
-	newVolume := api.Volume{}
-	if err = json.NewDecoder(res.Body).Decode(&newVolume); err != nil {
-		return api.Volume{}, err
-	}
-	return newVolume, reqErr
+	return ExpandWithContext(context.Background(), c, appID, volume)
+}
+
+// ExpandWithContext is like [Expand] but carries ctx.
+func ExpandWithContext(ctx context.Context, c *drycc.Client, appID string, volume api.Volume) (api.Volume, error) {
+	return drycc.Patch[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, volume.Name), volume)
 }
 
 // Serve serves an app's volume.
 func Serve(parent context.Context, c *drycc.Client, appID, name string) (context.Context, map[string]string, error) {
 	basePath := fmt.Sprintf("/v2/apps/%s/volumes/%s/filer", appID, name)
-	res, err := c.Request("POST", fmt.Sprintf("%s/_/bind", basePath), nil)
+	filer, err := drycc.Post[map[string]string](parent, c, fmt.Sprintf("%s/_/bind", basePath), nil)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer res.Body.Close()
-	var filer map[string]string
-	if err = json.NewDecoder(res.Body).Decode(&filer); err != nil {
-		return nil, nil, err
+	if filer == nil {
+		filer = map[string]string{}
 	}
 	ctx, cancel := context.WithCancel(parent)
 	go func() {
@@ -100,11 +75,7 @@ func Serve(parent context.Context, c *drycc.Client, appID, name string) (context
 			case <-parent.Done():
 				return
 			default:
-				res, err := c.Request("GET", fmt.Sprintf("%s/_/ping", basePath), nil)
-				if err != nil {
-					return
-				}
-				if err := res.Body.Close(); err != nil {
+				if err := drycc.Send(parent, c, "GET", fmt.Sprintf("%s/_/ping", basePath), nil); err != nil {
 					return
 				}
 				time.Sleep(time.Second * 30)
@@ -117,12 +88,12 @@ func Serve(parent context.Context, c *drycc.Client, appID, name string) (context
 
 // Delete delete an app's Volume.
 func Delete(c *drycc.Client, appID string, name string) error {
-	u := fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, name)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, appID, name)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, appID string, name string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/%s/", appID, name), nil)
 }
 
 // Mount mount an app's volume and creates a new release.
@@ -136,19 +107,10 @@ func Delete(c *drycc.Client, appID string, name string) error {
 // Calling Mount() with an empty api.Volume will return a drycc.ErrConflict.
 // Trying to Unmount a key that does not exist returns a drycc.ErrUnprocessable.
 func Mount(c *drycc.Client, appID string, name string, volume api.Volume) (api.Volume, error) {
-	body, err := json.Marshal(volume)
-	if err != nil {
-		return api.Volume{}, err
-	}
-	u := fmt.Sprintf("/v2/apps/%s/volumes/%s/path/", appID, name)
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil {
-		return api.Volume{}, reqErr
-	}
-	defer res.Body.Close()
-	newVolume := api.Volume{}
-	if err = json.NewDecoder(res.Body).Decode(&newVolume); err != nil {
-		return api.Volume{}, err
-	}
-	return newVolume, reqErr
+	return MountWithContext(context.Background(), c, appID, name, volume)
+}
+
+// MountWithContext is like [Mount] but carries ctx.
+func MountWithContext(ctx context.Context, c *drycc.Client, appID string, name string, volume api.Volume) (api.Volume, error) {
+	return drycc.Patch[api.Volume](ctx, c, fmt.Sprintf("/v2/apps/%s/volumes/%s/path/", appID, name), volume)
 }
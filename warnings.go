@@ -0,0 +1,175 @@
+package drycc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// maxWarnings is the number of distinct warnings a client records at most.
+const maxWarnings = 100
+
+// ErrDeprecated matches the errors of deprecation warnings with [errors.Is].
+var ErrDeprecated = errors.New("deprecated API")
+
+// WarningKind classifies a Warning.
+type WarningKind int
+
+const (
+	// WarningAPIMismatch reports a controller API version the SDK isn't compatible with.
+	WarningAPIMismatch WarningKind = iota + 1
+	// WarningDeprecation reports an endpoint the controller flagged as deprecated with a
+	// Deprecation header or a 299 Warning header.
+	WarningDeprecation
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case WarningAPIMismatch:
+		return "api mismatch"
+	case WarningDeprecation:
+		return "deprecation"
+	}
+	return fmt.Sprintf("WarningKind(%d)", int(k))
+}
+
+// Warning is a condition reported by the controller that doesn't prevent a request from
+// succeeding, unless the client is strict.
+type Warning struct {
+	// Kind classifies the warning.
+	Kind WarningKind
+	// Method and Path identify the request that raised the warning.
+	Method string
+	Path   string
+	// Err describes the warning. It is an *APIMismatchError for WarningAPIMismatch and
+	// matches ErrDeprecated for WarningDeprecation.
+	Err error
+}
+
+func (w Warning) String() string {
+	return w.Err.Error()
+}
+
+// Warnings returns the distinct warnings raised by the responses of the controller so far,
+// oldest first. A warning raised again by another request isn't recorded twice: an
+// endpoint is deprecated once, whatever the apps or other items the requests refer to.
+// Only the first 100 distinct warnings are recorded and passed to OnWarning.
+func (c *Client) Warnings() []Warning {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+	return slices.Clone(c.warnings)
+}
+
+// checkWarnings records the warnings raised by res. In strict mode the first of them is
+// returned as an error.
+func (c *Client) checkWarnings(res *http.Response) error {
+	var method, path string
+	if res.Request != nil {
+		method, path = res.Request.Method, res.Request.URL.Path
+	}
+
+	var warnings []Warning
+	if err := CheckAPICompatibility(res.Header.Get("DRYCC_API_VERSION"), APIVersion); err != nil {
+		warnings = append(warnings, Warning{Kind: WarningAPIMismatch, Method: method, Path: path, Err: err})
+	}
+	if err := deprecation(method, path, res.Header); err != nil {
+		warnings = append(warnings, Warning{Kind: WarningDeprecation, Method: method, Path: path, Err: err})
+	}
+
+	for _, w := range warnings {
+		c.warn(w)
+	}
+	if c.Strict && len(warnings) > 0 {
+		return warnings[0].Err
+	}
+	return nil
+}
+
+// warn records w and passes it to OnWarning, unless the same warning was seen before or
+// maxWarnings were recorded already.
+func (c *Client) warn(w Warning) {
+	c.warningsMu.Lock()
+	record := len(c.warnings) < maxWarnings && !slices.ContainsFunc(c.warnings, w.same)
+	if record {
+		c.warnings = append(c.warnings, w)
+	}
+	c.warningsMu.Unlock()
+
+	if record && c.OnWarning != nil {
+		c.OnWarning(w)
+	}
+}
+
+// same reports whether w and o are the same warning. A deprecation is raised by an
+// endpoint, while an API mismatch is raised by the controller as a whole.
+func (w Warning) same(o Warning) bool {
+	if w.Kind != o.Kind {
+		return false
+	}
+	if w.Kind == WarningDeprecation {
+		return w.Method == o.Method && endpoint(w.Path) == endpoint(o.Path)
+	}
+	return w.Err.Error() == o.Err.Error()
+}
+
+// itemCollections are the collections of the controller whose paths only continue with the
+// name of one of their items, such as an app in /v2/apps/example-go/config/. Collections
+// with actions next to their items, such as releases/rollback/, aren't among them.
+var itemCollections = map[string]bool{
+	"apps":        true,
+	"certs":       true,
+	"domain":      true,
+	"domains":     true,
+	"invitations": true,
+	"keys":        true,
+	"members":     true,
+	"perms":       true,
+	"pods":        true,
+	"routes":      true,
+	"tokens":      true,
+	"users":       true,
+	"volumes":     true,
+	"workspaces":  true,
+}
+
+// endpoint returns path with the names of the items of itemCollections replaced by {}, so
+// that /v2/apps/example-go/pods/ becomes /v2/apps/{}/pods/. Other segments are kept, as
+// they may name an action.
+func endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if itemCollections[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// deprecation returns an error matching ErrDeprecated if headers flag the endpoint as
+// deprecated, or nil.
+func deprecation(method, path string, headers http.Header) error {
+	var texts []string
+	for _, value := range headers.Values("Warning") {
+		// A warning value is made of a code, an agent and a quoted text.
+		code, rest, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if code != "299" {
+			continue
+		}
+		_, text, _ := strings.Cut(rest, " ")
+		texts = append(texts, strings.Trim(strings.TrimSpace(text), `"`))
+	}
+	if headers.Get("Deprecation") == "" && len(texts) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s %s", method, path)
+	if sunset := headers.Get("Sunset"); sunset != "" {
+		msg += fmt.Sprintf(" is removed after %s", sunset)
+	}
+	if len(texts) > 0 {
+		msg += ": " + strings.Join(texts, "; ")
+	}
+	return fmt.Errorf("%w: %s", ErrDeprecated, msg)
+}
@@ -0,0 +1,179 @@
+package drycc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeWarningServer struct{}
+
+func (fakeWarningServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("DRYCC_API_VERSION", APIVersion)
+
+	if strings.HasPrefix(req.URL.Path, "/v2/") {
+		res.Header().Add("Deprecation", "true")
+	}
+	switch req.URL.Path {
+	case "/deprecated/":
+		res.Header().Add("Deprecation", "true")
+		res.Header().Add("Sunset", "Fri, 01 Jan 2027 00:00:00 GMT")
+	case "/warning/":
+		res.Header().Add("Warning", `299 - "use /v3/ instead"`)
+		res.Header().Add("Warning", `199 - "not a deprecation"`)
+	}
+	res.Write([]byte(`{}`))
+}
+
+func TestWarnings(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeWarningServer{})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var seen []Warning
+	drycc.OnWarning = func(w Warning) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, w)
+	}
+
+	for _, path := range []string{"/ok/", "/deprecated/", "/deprecated/", "/warning/"} {
+		if err := Send(context.Background(), drycc, "GET", path, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	warnings := drycc.Warnings()
+	expected := []string{
+		"deprecated API: GET /deprecated/ is removed after Fri, 01 Jan 2027 00:00:00 GMT",
+		"deprecated API: GET /warning/: use /v3/ instead",
+	}
+	var actual []string
+	for _, w := range warnings {
+		if w.Kind != WarningDeprecation || !errors.Is(w.Err, ErrDeprecated) {
+			t.Errorf("Expected a %v warning, Got %v", WarningDeprecation, w.Kind)
+		}
+		actual = append(actual, w.String())
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+
+	if !reflect.DeepEqual(warnings, seen) {
+		t.Errorf("Expected %v, Got %v", warnings, seen)
+	}
+}
+
+func TestWarningsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeWarningServer{})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The deprecation of an endpoint is recorded once, whatever the app.
+	for _, app := range []string{"example-go", "example-python", "example-go"} {
+		for _, method := range []string{"GET", "POST"} {
+			if err := Send(context.Background(), drycc, method, "/v2/apps/"+app+"/run/", nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Actions of the same collection are distinct endpoints.
+	for _, path := range []string{"/v2/apps/example-go/releases/deploy/", "/v2/apps/example-go/releases/rollback/", "/v2/auth/login/", "/v2/auth/whoami/"} {
+		if err := Send(context.Background(), drycc, "POST", path, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var actual []string
+	for _, w := range drycc.Warnings() {
+		actual = append(actual, w.String())
+	}
+	expected := []string{
+		"deprecated API: GET /v2/apps/example-go/run/",
+		"deprecated API: POST /v2/apps/example-go/run/",
+		"deprecated API: POST /v2/apps/example-go/releases/deploy/",
+		"deprecated API: POST /v2/apps/example-go/releases/rollback/",
+		"deprecated API: POST /v2/auth/login/",
+		"deprecated API: POST /v2/auth/whoami/",
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, Got %v", expected, actual)
+	}
+
+	for path, expected := range map[string]string{
+		"/v2/apps/":                         "/v2/apps/",
+		"/v2/apps/example-go/":              "/v2/apps/{}/",
+		"/v2/apps/example-go/pods/web-1/":   "/v2/apps/{}/pods/{}/",
+		"/v2/apps/example-go/config/":       "/v2/apps/{}/config/",
+		"/v2/users/test/enable/":            "/v2/users/{}/enable/",
+		"/v2/apps/example-go/pods/web-1/x":  "/v2/apps/{}/pods/{}/x",
+		"/v2/apps/example-go/releases/v2/":  "/v2/apps/{}/releases/v2/",
+		"/v2/apps/example-go/ptypes/scale/": "/v2/apps/{}/ptypes/scale/",
+		"/v2/auth/whoami/":                  "/v2/auth/whoami/",
+		"/v2/admin/perms/test/":             "/v2/admin/perms/{}/",
+		"/v2/resources/services/":           "/v2/resources/services/",
+	} {
+		if actual := endpoint(path); actual != expected {
+			t.Errorf("Expected %s, Got %s", expected, actual)
+		}
+	}
+}
+
+func TestWarningsLimit(t *testing.T) {
+	t.Parallel()
+
+	drycc, err := New(false, "drycc.example.com", "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	drycc.OnWarning = func(Warning) { calls++ }
+
+	for i := range maxWarnings + 10 {
+		path := "/v2/endpoint" + strconv.Itoa(i) + "/"
+		drycc.warn(Warning{Kind: WarningDeprecation, Method: "GET", Path: path, Err: deprecation("GET", path, http.Header{"Deprecation": {"true"}})})
+	}
+	if len(drycc.Warnings()) != maxWarnings || calls != maxWarnings {
+		t.Errorf("Expected %d warnings, Got %d and %d calls", maxWarnings, len(drycc.Warnings()), calls)
+	}
+}
+
+func TestWarningsStrict(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(fakeWarningServer{})
+	defer server.Close()
+
+	drycc, err := New(false, server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drycc.Strict = true
+
+	if err := Send(context.Background(), drycc, "GET", "/ok/", nil); err != nil {
+		t.Error(err)
+	}
+	if err := Send(context.Background(), drycc, "GET", "/deprecated/", nil); !errors.Is(err, ErrDeprecated) {
+		t.Errorf("Expected %v, Got %v", ErrDeprecated, err)
+	}
+	if warnings := drycc.Warnings(); len(warnings) != 1 {
+		t.Errorf("Expected 1 warning, Got %v", warnings)
+	}
+}
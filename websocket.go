@@ -0,0 +1,33 @@
+package drycc
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+// NewWebsocketConfig returns the configuration to open a websocket to the path on the
+// controller. The websocket is authenticated like other requests and uses the client's
+// TLS configuration.
+func (c *Client) NewWebsocketConfig(path string) (*websocket.Config, error) {
+	scheme := "ws"
+	if c.ControllerURL.Scheme == "https" {
+		scheme = "wss"
+	}
+	u := url.URL{Scheme: scheme, Host: c.ControllerURL.Host, Path: "/" + strings.TrimPrefix(path, "/")}
+	config, err := websocket.NewConfig(u.String(), c.ControllerURL.String())
+	if err != nil {
+		return nil, err
+	}
+	config.Header = http.Header{
+		"User-Agent":          {c.UserAgent},
+		"Authorization":       {"token " + c.CurrentToken()},
+		"X-Drycc-Service-Key": {c.ServiceKey},
+	}
+	if c.TLSConfig != nil {
+		config.TlsConfig = c.TLSConfig.Clone()
+	}
+	return config, nil
+}
@@ -2,8 +2,9 @@
 package invitations
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,67 +12,47 @@ import (
 
 // List lists pending invitations in a workspace.
 func List(c *drycc.Client, workspace string, results int) (api.WorkspaceInvitations, int, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/invitations", workspace)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.WorkspaceInvitation{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, workspace, results)
+}
 
-	var invitations []api.WorkspaceInvitation
-	if err := json.Unmarshal([]byte(body), &invitations); err != nil {
-		return []api.WorkspaceInvitation{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, workspace string, results int) (api.WorkspaceInvitations, int, error) {
+	return drycc.List[api.WorkspaceInvitation](ctx, c, fmt.Sprintf("/v2/workspaces/%s/invitations", workspace), results)
+}
 
-	return invitations, count, reqErr
+// All returns an iterator over every invitation of a workspace, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, workspace string, pageSize int) iter.Seq2[api.WorkspaceInvitation, error] {
+	return drycc.All[api.WorkspaceInvitation](ctx, c, fmt.Sprintf("/v2/workspaces/%s/invitations", workspace), pageSize)
 }
 
 // Create creates a workspace invitation.
 func Create(c *drycc.Client, workspace, email string) (api.WorkspaceInvitation, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/invitations", workspace)
-	req := api.WorkspaceInvitationCreateRequest{Email: email}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.WorkspaceInvitation{}, err
-	}
-
-	res, reqErr := c.Request("POST", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.WorkspaceInvitation{}, reqErr
-	}
-	defer res.Body.Close()
-
-	invitation := api.WorkspaceInvitation{}
-	if err = json.NewDecoder(res.Body).Decode(&invitation); err != nil {
-		return api.WorkspaceInvitation{}, err
-	}
+	return CreateWithContext(context.Background(), c, workspace, email)
+}
 
-	return invitation, reqErr
+// CreateWithContext is like [Create] but carries ctx.
+func CreateWithContext(ctx context.Context, c *drycc.Client, workspace, email string) (api.WorkspaceInvitation, error) {
+	req := api.WorkspaceInvitationCreateRequest{Email: email}
+	return drycc.Post[api.WorkspaceInvitation](ctx, c, fmt.Sprintf("/v2/workspaces/%s/invitations", workspace), req)
 }
 
 // Get fetches an invitation by uid token.
 func Get(c *drycc.Client, workspace, uid string) (api.WorkspaceInvitation, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/invitations/%s", workspace, uid)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.WorkspaceInvitation{}, reqErr
-	}
-	defer res.Body.Close()
-
-	invitation := api.WorkspaceInvitation{}
-	if err := json.NewDecoder(res.Body).Decode(&invitation); err != nil {
-		return api.WorkspaceInvitation{}, err
-	}
+	return GetWithContext(context.Background(), c, workspace, uid)
+}
 
-	return invitation, reqErr
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, workspace, uid string) (api.WorkspaceInvitation, error) {
+	return drycc.Get[api.WorkspaceInvitation](ctx, c, fmt.Sprintf("/v2/workspaces/%s/invitations/%s", workspace, uid))
 }
 
 // Delete revokes an invitation.
 func Delete(c *drycc.Client, workspace, uid string) error {
-	u := fmt.Sprintf("/v2/workspaces/%s/invitations/%s", workspace, uid)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, workspace, uid)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, workspace, uid string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/workspaces/%s/invitations/%s", workspace, uid), nil)
 }
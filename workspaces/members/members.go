@@ -2,8 +2,9 @@
 package members
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,67 +12,47 @@ import (
 
 // List lists members in a workspace.
 func List(c *drycc.Client, workspace string, results int) (api.WorkspaceMembers, int, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/members", workspace)
-	body, count, reqErr := c.LimitedRequest(u, results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.WorkspaceMember{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, workspace, results)
+}
 
-	var members []api.WorkspaceMember
-	if err := json.Unmarshal([]byte(body), &members); err != nil {
-		return []api.WorkspaceMember{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, workspace string, results int) (api.WorkspaceMembers, int, error) {
+	return drycc.List[api.WorkspaceMember](ctx, c, fmt.Sprintf("/v2/workspaces/%s/members", workspace), results)
+}
 
-	return members, count, reqErr
+// All returns an iterator over every member of a workspace, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, workspace string, pageSize int) iter.Seq2[api.WorkspaceMember, error] {
+	return drycc.All[api.WorkspaceMember](ctx, c, fmt.Sprintf("/v2/workspaces/%s/members", workspace), pageSize)
 }
 
 // Get fetches a workspace member by username.
 func Get(c *drycc.Client, workspace, user string) (api.WorkspaceMember, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.WorkspaceMember{}, reqErr
-	}
-	defer res.Body.Close()
-
-	member := api.WorkspaceMember{}
-	if err := json.NewDecoder(res.Body).Decode(&member); err != nil {
-		return api.WorkspaceMember{}, err
-	}
+	return GetWithContext(context.Background(), c, workspace, user)
+}
 
-	return member, reqErr
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, workspace, user string) (api.WorkspaceMember, error) {
+	return drycc.Get[api.WorkspaceMember](ctx, c, fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user))
 }
 
 // Update updates a workspace member role/alerts.
 func Update(c *drycc.Client, workspace, user, role string, alerts *bool) (api.WorkspaceMember, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user)
-	req := api.WorkspaceMemberUpdateRequest{Role: role, Alerts: alerts}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.WorkspaceMember{}, err
-	}
-
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.WorkspaceMember{}, reqErr
-	}
-	defer res.Body.Close()
-
-	member := api.WorkspaceMember{}
-	if err = json.NewDecoder(res.Body).Decode(&member); err != nil {
-		return api.WorkspaceMember{}, err
-	}
+	return UpdateWithContext(context.Background(), c, workspace, user, role, alerts)
+}
 
-	return member, reqErr
+// UpdateWithContext is like [Update] but carries ctx.
+func UpdateWithContext(ctx context.Context, c *drycc.Client, workspace, user, role string, alerts *bool) (api.WorkspaceMember, error) {
+	req := api.WorkspaceMemberUpdateRequest{Role: role, Alerts: alerts}
+	return drycc.Patch[api.WorkspaceMember](ctx, c, fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user), req)
 }
 
 // Delete removes a workspace member.
 func Delete(c *drycc.Client, workspace, user string) error {
-	u := fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, workspace, user)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, workspace, user string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/workspaces/%s/members/%s", workspace, user), nil)
 }
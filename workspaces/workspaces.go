@@ -2,8 +2,9 @@
 package workspaces
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"iter"
 
 	drycc "github.com/drycc/controller-sdk-go"
 	"github.com/drycc/controller-sdk-go/api"
@@ -11,88 +12,58 @@ import (
 
 // List lists workspaces visible to the current user.
 func List(c *drycc.Client, results int) (api.Workspaces, int, error) {
-	body, count, reqErr := c.LimitedRequest("/v2/workspaces", results)
-
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return []api.Workspace{}, -1, reqErr
-	}
+	return ListWithContext(context.Background(), c, results)
+}
 
-	var workspaces []api.Workspace
-	if err := json.Unmarshal([]byte(body), &workspaces); err != nil {
-		return []api.Workspace{}, -1, err
-	}
+// ListWithContext is like [List] but carries ctx.
+func ListWithContext(ctx context.Context, c *drycc.Client, results int) (api.Workspaces, int, error) {
+	return drycc.List[api.Workspace](ctx, c, "/v2/workspaces", results)
+}
 
-	return workspaces, count, reqErr
+// All returns an iterator over every workspace of the user, following the controller's
+// pagination and fetching pageSize results per request.
+func All(ctx context.Context, c *drycc.Client, pageSize int) iter.Seq2[api.Workspace, error] {
+	return drycc.All[api.Workspace](ctx, c, "/v2/workspaces", pageSize)
 }
 
 // Create creates a workspace.
 func Create(c *drycc.Client, name, email string) (api.Workspace, error) {
-	req := api.WorkspaceCreateRequest{Name: name, Email: email}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Workspace{}, err
-	}
-
-	res, reqErr := c.Request("POST", "/v2/workspaces", body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Workspace{}, reqErr
-	}
-	defer res.Body.Close()
-
-	workspace := api.Workspace{}
-	if err = json.NewDecoder(res.Body).Decode(&workspace); err != nil {
-		return api.Workspace{}, err
-	}
+	return CreateWithContext(context.Background(), c, name, email)
+}
 
-	return workspace, reqErr
+// CreateWithContext is like [Create] but carries ctx.
+func CreateWithContext(ctx context.Context, c *drycc.Client, name, email string) (api.Workspace, error) {
+	req := api.WorkspaceCreateRequest{Name: name, Email: email}
+	return drycc.Post[api.Workspace](ctx, c, "/v2/workspaces", req)
 }
 
 // Get fetches a workspace by name.
 func Get(c *drycc.Client, name string) (api.Workspace, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s", name)
-	res, reqErr := c.Request("GET", u, nil)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Workspace{}, reqErr
-	}
-	defer res.Body.Close()
-
-	workspace := api.Workspace{}
-	if err := json.NewDecoder(res.Body).Decode(&workspace); err != nil {
-		return api.Workspace{}, err
-	}
+	return GetWithContext(context.Background(), c, name)
+}
 
-	return workspace, reqErr
+// GetWithContext is like [Get] but carries ctx.
+func GetWithContext(ctx context.Context, c *drycc.Client, name string) (api.Workspace, error) {
+	return drycc.Get[api.Workspace](ctx, c, fmt.Sprintf("/v2/workspaces/%s", name))
 }
 
 // Update updates workspace attributes.
 func Update(c *drycc.Client, name, email string) (api.Workspace, error) {
-	u := fmt.Sprintf("/v2/workspaces/%s", name)
-	req := api.WorkspaceUpdateRequest{Email: email}
-	body, err := json.Marshal(req)
-	if err != nil {
-		return api.Workspace{}, err
-	}
-
-	res, reqErr := c.Request("PATCH", u, body)
-	if reqErr != nil && !drycc.IsErrAPIMismatch(reqErr) {
-		return api.Workspace{}, reqErr
-	}
-	defer res.Body.Close()
-
-	workspace := api.Workspace{}
-	if err = json.NewDecoder(res.Body).Decode(&workspace); err != nil {
-		return api.Workspace{}, err
-	}
+	return UpdateWithContext(context.Background(), c, name, email)
+}
 
-	return workspace, reqErr
+// UpdateWithContext is like [Update] but carries ctx.
+func UpdateWithContext(ctx context.Context, c *drycc.Client, name, email string) (api.Workspace, error) {
+	req := api.WorkspaceUpdateRequest{Email: email}
+	return drycc.Patch[api.Workspace](ctx, c, fmt.Sprintf("/v2/workspaces/%s", name), req)
 }
 
 // Delete removes a workspace.
 func Delete(c *drycc.Client, name string) error {
-	u := fmt.Sprintf("/v2/workspaces/%s", name)
-	res, err := c.Request("DELETE", u, nil)
-	if err == nil {
-		res.Body.Close()
-	}
-	return err
+	return DeleteWithContext(context.Background(), c, name)
+}
+
+// DeleteWithContext is like [Delete] but carries ctx.
+func DeleteWithContext(ctx context.Context, c *drycc.Client, name string) error {
+	return drycc.Delete(ctx, c, fmt.Sprintf("/v2/workspaces/%s", name), nil)
 }